@@ -1,19 +1,61 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/ethaan/miracle74-api/internal/api"
+	"github.com/ethaan/miracle74-api/internal/freshness"
 	"github.com/ethaan/miracle74-api/internal/handlers"
+	"github.com/ethaan/miracle74-api/internal/logging"
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/services"
+	"github.com/ethaan/miracle74-api/internal/telemetry"
 	"github.com/ethaan/miracle74-api/pkg/cache"
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+	"go.opentelemetry.io/otel"
+)
+
+// shutdownTimeout is how long in-flight requests get to finish after a stop
+// signal, and flushTimeout how long pending spans then get to be exported.
+// Together they stay below fly.toml's kill_timeout.
+const (
+	shutdownTimeout = 20 * time.Second
+	flushTimeout    = 5 * time.Second
 )
 
 func main() {
+	// JSON logs on Fly, readable text locally, unless LOG_FORMAT says otherwise
+	logFormat := os.Getenv("LOG_FORMAT")
+	if logFormat == "" && os.Getenv("FLY_APP_NAME") != "" {
+		logFormat = "json"
+	}
+	logger := logging.New(os.Stdout, logFormat, os.Getenv("LOG_LEVEL"))
+	slog.SetDefault(logger)
+
+	// Tracing is exported over OTLP when OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := telemetry.Setup(context.Background())
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	if telemetry.Enabled() {
+		logger.Info("exporting traces over OTLP")
+	}
+
+	metricsHandler, err := telemetry.SetupMetrics()
+	if err != nil {
+		logger.Error("failed to set up metrics", "error", err)
+		os.Exit(1)
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -24,45 +66,164 @@ func main() {
 		cacheURL = "localhost:6379"
 	}
 
-	cacheClient, err := cache.NewClient(cacheURL, cache.DefaultTTL)
-	if err != nil {
-		log.Fatalf("Failed to connect to cache: %v", err)
+	// Valkey is shared by every instance. Without it, at startup or during an
+	// outage, each instance caches in its own memory and reports degraded.
+	var primary cache.Backend
+	if cacheURL == "memory" {
+		logger.Info("caching in memory only")
+	} else if valkeyBackend, err := cache.NewValkeyBackend(cacheURL); err != nil {
+		logger.Warn("cache unavailable, caching in memory only", "addr", cacheURL, "error", err)
+	} else {
+		primary = valkeyBackend
+		logger.Info("connected to Valkey cache", "addr", cacheURL)
 	}
+	memoryBackend := cache.NewMemoryBackend(getEnvInt("CACHE_MEMORY_ENTRIES", cache.DefaultMemoryEntries), 0)
+	var backend cache.Backend = cache.NewFallbackBackend(primary, memoryBackend)
+
+	// Entries scraped by another parser version are ignored and scraped again
+	cacheOpts := []cache.Option{cache.WithVersion(miracle74.ParserVersion)}
+	// A short-lived in-process copy of hot entries, already decoded, saves a
+	// Valkey round trip and the decode. CACHE_L1_TTL=0 turns it off.
+	if l1TTL := getEnvDuration("CACHE_L1_TTL", cache.DefaultL1TTL); primary != nil && l1TTL > 0 {
+		cacheOpts = append(cacheOpts, cache.WithL1(getEnvInt("CACHE_L1_ENTRIES", 1000), l1TTL))
+	}
+	cacheClient := cache.New(backend, cache.DefaultTTL, cacheOpts...)
 	defer cacheClient.Close()
-	log.Printf("Connected to Valkey cache at %s", cacheURL)
 
 	// Repos
 	characterRepo := repo.NewCharacterRepo(cacheClient)
 	powerGamersRepo := repo.NewPowerGamersRepo(cacheClient)
 	insomniacsRepo := repo.NewInsomniacsRepo(cacheClient)
 	guildRepo := repo.NewGuildRepo(cacheClient)
+	guildDirectoryRepo := repo.NewGuildDirectoryRepo(cacheClient)
 	whoIsOnlineRepo := repo.NewWhoIsOnlineRepo(cacheClient)
 
+	// Upstream client, shared by every service so they draw from one request budget
+	scheduler := miracle74.NewScheduler(miracle74.SchedulerConfig{
+		RequestsPerMinute: getEnvInt("UPSTREAM_RPM", miracle74.DefaultRequestsPerMinute),
+		MaxConcurrent:     getEnvInt("UPSTREAM_CONCURRENCY", miracle74.DefaultMaxConcurrent),
+		Jitter:            miracle74.DefaultJitter,
+	})
+	scraperOpts := []miracle74.Option{
+		miracle74.WithLogger(logger),
+		miracle74.WithScheduler(scheduler),
+		miracle74.WithRetryPolicy(miracle74.RetryPolicy{
+			MaxAttempts: getEnvInt("UPSTREAM_MAX_ATTEMPTS", miracle74.DefaultMaxAttempts),
+		}),
+	}
+	if upstreamURL := os.Getenv("UPSTREAM_URL"); upstreamURL != "" {
+		scraperOpts = append(scraperOpts, miracle74.WithBaseURL(upstreamURL))
+		logger.Info("scraping alternate upstream", "url", upstreamURL)
+	}
+	if tz := os.Getenv("UPSTREAM_TZ"); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			scraperOpts = append(scraperOpts, miracle74.WithLocation(loc))
+		} else {
+			logger.Warn("invalid timezone, using default", "key", "UPSTREAM_TZ", "value", tz, "default", miracle74.DefaultTimezone)
+		}
+	}
+	scraper := miracle74.NewClient(scraperOpts...)
+
 	// Services
-	characterService := services.NewCharacterService(characterRepo)
-	powerGamersService := services.NewPowerGamersService(powerGamersRepo)
-	insomniacsService := services.NewInsomniacsService(insomniacsRepo)
-	guildService := services.NewGuildService(guildRepo)
-	whoIsOnlineService := services.NewWhoIsOnlineService(whoIsOnlineRepo)
+	characterService := services.NewCharacterService(scraper, characterRepo)
+	powerGamersService := services.NewPowerGamersService(scraper, powerGamersRepo)
+	insomniacsService := services.NewInsomniacsService(scraper, insomniacsRepo)
+	guildService := services.NewGuildService(scraper, guildRepo, guildDirectoryRepo, characterRepo)
+	whoIsOnlineService := services.NewWhoIsOnlineService(scraper, whoIsOnlineRepo, characterRepo)
 
 	// Handlers
-	handler := handlers.NewHandler(characterService, powerGamersService, insomniacsService, guildService, whoIsOnlineService)
+	handler := handlers.NewHandler(characterService, powerGamersService, insomniacsService, guildService, whoIsOnlineService, cacheClient)
 
-	srv, err := api.NewServer(handler)
+	srv, err := api.NewServer(handler,
+		api.WithMiddleware(logging.Middleware(logger)),
+		api.WithTracerProvider(otel.GetTracerProvider()),
+		api.WithMeterProvider(otel.GetMeterProvider()),
+	)
 	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
+		logger.Error("failed to create server", "error", err)
+		os.Exit(1)
 	}
 
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler)
+	mux.Handle("/", freshness.Middleware(srv))
+
 	httpServer := &http.Server{
 		Addr:         ":" + port,
-		Handler:      srv,
+		Handler:      mux,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 60 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	log.Printf("Starting server on port %s", port)
-	if err := httpServer.ListenAndServe(); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	// Fly stops machines with SIGINT, docker with SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("starting server", "port", port)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	exitCode := 0
+	select {
+	case err := <-serveErr:
+		logger.Error("server failed", "error", err)
+		exitCode = 1
+	case <-ctx.Done():
+		logger.Info("shutting down, draining requests", "timeout", shutdownTimeout)
+		stop() // A second signal kills the process right away.
+
+		drainCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		if err := httpServer.Shutdown(drainCtx); err != nil {
+			logger.Error("failed to drain requests", "error", err)
+			exitCode = 1
+		}
+		cancel()
+		if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server failed", "error", err)
+			exitCode = 1
+		}
+	}
+
+	// Only now that no request is left to record spans, flush them.
+	flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+		exitCode = 1
+	}
+
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("invalid integer setting, using default", "key", key, "value", value, "default", fallback)
+		return fallback
+	}
+	return n
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("invalid duration setting, using default", "key", key, "value", value, "default", fallback)
+		return fallback
 	}
+	return d
 }
@@ -0,0 +1,80 @@
+// Command cacheadmin purges entries from the shared Valkey cache.
+//
+//	cacheadmin [-url addr] [-all] [-dry-run] prefix
+//
+// By default it deletes the entries under prefix (e.g. "character:") that
+// were stored by another parser version than miracle74.ParserVersion, or
+// that can't be read. The API already ignores those, but they keep taking
+// up memory until their hard TTL runs out. With -all every entry under
+// prefix is deleted. Locks are never touched. The cache URL defaults to
+// CACHE_URL.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/ethaan/miracle74-api/pkg/cache"
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+func main() {
+	cacheURL := os.Getenv("CACHE_URL")
+	if cacheURL == "" {
+		cacheURL = "localhost:6379"
+	}
+
+	flag.StringVar(&cacheURL, "url", cacheURL, "Valkey address or redis:// URL")
+	all := flag.Bool("all", false, "delete every entry under prefix, not only outdated ones")
+	dryRun := flag.Bool("dry-run", false, "list the entries that would be deleted")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] prefix\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	prefix := flag.Arg(0)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := cache.NewClient(cacheURL, cache.DefaultTTL, cache.WithVersion(miracle74.ParserVersion))
+	if err != nil {
+		log.Fatalf("Failed to connect to cache: %v", err)
+	}
+	defer client.Close()
+
+	var keys []string
+	if *all {
+		keys, err = client.Keys(ctx, prefix)
+	} else {
+		keys, err = client.Outdated(ctx, prefix)
+	}
+	if err != nil {
+		log.Fatalf("Failed to list entries: %v", err)
+	}
+
+	for _, key := range keys {
+		if *dryRun {
+			fmt.Println(key)
+			continue
+		}
+		if err := client.Delete(ctx, key); err != nil {
+			log.Fatalf("Failed to delete %s: %v", key, err)
+		}
+	}
+
+	if *dryRun {
+		log.Printf("Would delete %d entries under %q", len(keys), prefix)
+	} else {
+		log.Printf("Deleted %d entries under %q", len(keys), prefix)
+	}
+}
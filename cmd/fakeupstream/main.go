@@ -0,0 +1,236 @@
+// Command fakeupstream is a stand-in for miracle74.com. It serves the HTML
+// fixtures from pkg/miracle74/testdata using the same ?subtopic=... routing
+// the scraper builds, and can inject faults so retries, caching and error
+// mapping can be exercised without touching the real site.
+//
+// Faults are configured with FAULT_MODE (slow, ratelimit, 5xx, truncate or
+// classes), FAULT_RATE (fraction of requests affected, default 1),
+// FAULT_DELAY (for slow) and FAULT_RETRY_AFTER (for ratelimit). They can be
+// changed at runtime with GET /_fault?mode=...&rate=...
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+const (
+	faultNone      = ""
+	faultSlow      = "slow"
+	faultRateLimit = "ratelimit"
+	faultServer    = "5xx"
+	faultTruncate  = "truncate"
+	faultClasses   = "classes"
+)
+
+type faultConfig struct {
+	Mode       string
+	Rate       float64
+	Delay      time.Duration
+	RetryAfter int
+}
+
+type server struct {
+	fixturesDir string
+
+	mu    sync.RWMutex
+	fault faultConfig
+}
+
+func main() {
+	port := os.Getenv("FAKEUPSTREAM_PORT")
+	if port == "" {
+		port = "8090"
+	}
+
+	fixturesDir := os.Getenv("FIXTURES_DIR")
+	if fixturesDir == "" {
+		fixturesDir = "pkg/miracle74/testdata"
+	}
+
+	s := &server{
+		fixturesDir: fixturesDir,
+		fault: faultConfig{
+			Mode:       os.Getenv("FAULT_MODE"),
+			Rate:       getEnvFloat("FAULT_RATE", 1),
+			Delay:      getEnvDuration("FAULT_DELAY", 10*time.Second),
+			RetryAfter: int(getEnvFloat("FAULT_RETRY_AFTER", 5)),
+		},
+	}
+
+	http.HandleFunc("/_fault", s.handleFault)
+	http.HandleFunc("/", s.handlePage)
+
+	log.Printf("Fake miracle74.com serving %s on http://localhost:%s", fixturesDir, port)
+	if s.fault.Mode != faultNone {
+		log.Printf("Injecting %q faults into %.0f%% of requests", s.fault.Mode, s.fault.Rate*100)
+	}
+
+	if err := http.ListenAndServe(":"+port, nil); err != nil {
+		log.Fatalf("Failed to start fake upstream: %v", err)
+	}
+}
+
+// handleFault shows the current fault configuration and updates it from
+// the mode, rate, delay and retry_after query parameters.
+func (s *server) handleFault(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	if q.Has("mode") {
+		s.fault.Mode = q.Get("mode")
+	}
+	if rate, err := strconv.ParseFloat(q.Get("rate"), 64); err == nil {
+		s.fault.Rate = rate
+	}
+	if delay, err := time.ParseDuration(q.Get("delay")); err == nil {
+		s.fault.Delay = delay
+	}
+	if retryAfter, err := strconv.Atoi(q.Get("retry_after")); err == nil {
+		s.fault.RetryAfter = retryAfter
+	}
+	fault := s.fault
+	s.mu.Unlock()
+
+	log.Printf("Fault config: %+v", fault)
+	fmt.Fprintf(w, "%+v\n", fault)
+}
+
+func (s *server) handlePage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	body, status := s.lookup(query)
+	log.Printf("%s %s -> %d", r.Method, r.URL.RequestURI(), status)
+
+	s.mu.RLock()
+	fault := s.fault
+	s.mu.RUnlock()
+
+	if fault.Mode != faultNone && rand.Float64() < fault.Rate {
+		log.Printf("Injecting %q fault", fault.Mode)
+
+		switch fault.Mode {
+		case faultSlow:
+			select {
+			case <-time.After(fault.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		case faultRateLimit:
+			w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		case faultServer:
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		case faultTruncate:
+			body = body[:len(body)/2]
+		case faultClasses:
+			// The replacement must not contain the old class, which the
+			// parser matches as a substring.
+			body = bytes.ReplaceAll(body, []byte("TableContent"), []byte("Listing"))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// lookup finds the fixture for query. Unknown characters and guilds get the
+// site's "does not exist" page; other lists fall back to their default
+// filters so any list or order can be browsed.
+func (s *server) lookup(query url.Values) ([]byte, int) {
+	if body, err := os.ReadFile(filepath.Join(s.fixturesDir, miracle74.FixtureName(query))); err == nil {
+		return body, http.StatusOK
+	}
+
+	switch query.Get("subtopic") {
+	case "characters":
+		// The site matches character names regardless of case.
+		if body, ok := s.findFold(miracle74.FixtureName(query)); ok {
+			return body, http.StatusOK
+		}
+		return notFoundPage(fmt.Sprintf("Character <b>%s</b> does not exist.", html.EscapeString(query.Get("name")))), http.StatusOK
+	case "guilds":
+		return notFoundPage(fmt.Sprintf("Guild with ID %s doesn't exist.", html.EscapeString(query.Get("guild")))), http.StatusOK
+	}
+
+	fallback := url.Values{}
+	fallback.Set("subtopic", query.Get("subtopic"))
+	for key, value := range map[string]string{"list": "today", "order": "name"} {
+		if query.Has(key) {
+			fallback.Set(key, value)
+		}
+	}
+	if query.Has("page") {
+		fallback.Set("page", query.Get("page"))
+	}
+
+	if body, err := os.ReadFile(filepath.Join(s.fixturesDir, miracle74.FixtureName(fallback))); err == nil {
+		return body, http.StatusOK
+	}
+	return []byte("Not Found"), http.StatusNotFound
+}
+
+// findFold reads the fixture whose file name matches name ignoring case.
+func (s *server) findFold(name string) ([]byte, bool) {
+	entries, err := os.ReadDir(s.fixturesDir)
+	if err != nil {
+		return nil, false
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			body, err := os.ReadFile(filepath.Join(s.fixturesDir, entry.Name()))
+			return body, err == nil
+		}
+	}
+	return nil, false
+}
+
+func notFoundPage(message string) []byte {
+	return []byte(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Miracle 74</title></head>
+<body>
+<div id="ContentColumn">
+<div class="TableContainer">
+  <div class="CaptionContainer"><div class="CaptionInnerContainer"><div class="Text">Error</div></div></div>
+<table class="Table1">
+  <tr><td>` + message + `</td></tr>
+</table>
+</div>
+</div>
+</body>
+</html>
+`)
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+func TestClassesFaultBreaksParsing(t *testing.T) {
+	s := &server{fixturesDir: "../../pkg/miracle74/testdata"}
+	srv := httptest.NewServer(http.HandlerFunc(s.handlePage))
+	defer srv.Close()
+
+	client := miracle74.NewClient(
+		miracle74.WithBaseURL(srv.URL),
+		miracle74.WithScheduler(miracle74.NewScheduler(miracle74.SchedulerConfig{RequestsPerMinute: 60000, MaxConcurrent: 4})),
+	)
+	ctx := context.Background()
+
+	if _, err := client.ScrapeCharacter(ctx, "Oten"); err != nil {
+		t.Fatalf("ScrapeCharacter() without faults error = %v", err)
+	}
+
+	s.fault = faultConfig{Mode: faultClasses, Rate: 1}
+
+	if _, err := client.ScrapeCharacter(ctx, "Oten"); !errors.Is(err, miracle74.ErrParse) {
+		t.Errorf("ScrapeCharacter() error = %v, want ErrParse", err)
+	}
+	if _, err := client.ScrapeWhoIsOnline(ctx, "name"); !errors.Is(err, miracle74.ErrParse) {
+		t.Errorf("ScrapeWhoIsOnline() error = %v, want ErrParse", err)
+	}
+}
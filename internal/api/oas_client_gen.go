@@ -39,6 +39,13 @@ type Invoker interface {
 	//
 	// GET /guilds/{guildId}
 	GetGuild(ctx context.Context, params GetGuildParams) (GetGuildRes, error)
+	// GetGuildByName invokes getGuildByName operation.
+	//
+	// Resolves the guild name through the guild list, ignoring case, and returns the same data as
+	// getGuild.
+	//
+	// GET /guilds/by-name/{name}
+	GetGuildByName(ctx context.Context, params GetGuildByNameParams) (GetGuildByNameRes, error)
 	// GetHealth invokes getHealth operation.
 	//
 	// Returns the current health status of the API.
@@ -65,6 +72,12 @@ type Invoker interface {
 	//
 	// GET /whoisonline
 	GetWhoIsOnline(ctx context.Context, params GetWhoIsOnlineParams) (GetWhoIsOnlineRes, error)
+	// ListGuilds invokes listGuilds operation.
+	//
+	// Returns every guild on the guild list with its description and member count.
+	//
+	// GET /guilds
+	ListGuilds(ctx context.Context) (ListGuildsRes, error)
 }
 
 // Client implements OAS client.
@@ -292,6 +305,98 @@ func (c *Client) sendGetGuild(ctx context.Context, params GetGuildParams) (res G
 	return result, nil
 }
 
+// GetGuildByName invokes getGuildByName operation.
+//
+// Resolves the guild name through the guild list, ignoring case, and returns the same data as
+// getGuild.
+//
+// GET /guilds/by-name/{name}
+func (c *Client) GetGuildByName(ctx context.Context, params GetGuildByNameParams) (GetGuildByNameRes, error) {
+	res, err := c.sendGetGuildByName(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGetGuildByName(ctx context.Context, params GetGuildByNameParams) (res GetGuildByNameRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getGuildByName"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/guilds/by-name/{name}"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetGuildByNameOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/guilds/by-name/"
+	{
+		// Encode "name" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "name",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.StringToString(params.Name))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetGuildByNameResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // GetHealth invokes getHealth operation.
 //
 // Returns the current health status of the API.
@@ -683,3 +788,76 @@ func (c *Client) sendGetWhoIsOnline(ctx context.Context, params GetWhoIsOnlinePa
 
 	return result, nil
 }
+
+// ListGuilds invokes listGuilds operation.
+//
+// Returns every guild on the guild list with its description and member count.
+//
+// GET /guilds
+func (c *Client) ListGuilds(ctx context.Context) (ListGuildsRes, error) {
+	res, err := c.sendListGuilds(ctx)
+	return res, err
+}
+
+func (c *Client) sendListGuilds(ctx context.Context) (res ListGuildsRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("listGuilds"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.URLTemplateKey.String("/guilds"),
+	}
+	otelAttrs = append(otelAttrs, c.cfg.Attributes...)
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ListGuildsOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/guilds"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeListGuildsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
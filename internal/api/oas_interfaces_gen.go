@@ -5,6 +5,10 @@ type GetCharacterRes interface {
 	getCharacterRes()
 }
 
+type GetGuildByNameRes interface {
+	getGuildByNameRes()
+}
+
 type GetGuildRes interface {
 	getGuildRes()
 }
@@ -20,3 +24,7 @@ type GetPowerGamersRes interface {
 type GetWhoIsOnlineRes interface {
 	getWhoIsOnlineRes()
 }
+
+type ListGuildsRes interface {
+	listGuildsRes()
+}
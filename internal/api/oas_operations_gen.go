@@ -8,8 +8,10 @@ type OperationName = string
 const (
 	GetCharacterOperation   OperationName = "GetCharacter"
 	GetGuildOperation       OperationName = "GetGuild"
+	GetGuildByNameOperation OperationName = "GetGuildByName"
 	GetHealthOperation      OperationName = "GetHealth"
 	GetInsomniacsOperation  OperationName = "GetInsomniacs"
 	GetPowerGamersOperation OperationName = "GetPowerGamers"
 	GetWhoIsOnlineOperation OperationName = "GetWhoIsOnline"
+	ListGuildsOperation     OperationName = "ListGuilds"
 )
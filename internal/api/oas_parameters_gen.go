@@ -16,7 +16,7 @@ import (
 
 // GetCharacterParams is parameters of getCharacter operation.
 type GetCharacterParams struct {
-	// The character name.
+	// The character name. Case and surrounding whitespace are ignored.
 	Name string
 }
 
@@ -146,9 +146,76 @@ func decodeGetGuildParams(args [1]string, argsEscaped bool, r *http.Request) (pa
 	return params, nil
 }
 
+// GetGuildByNameParams is parameters of getGuildByName operation.
+type GetGuildByNameParams struct {
+	// Guild name.
+	Name string
+}
+
+func unpackGetGuildByNameParams(packed middleware.Parameters) (params GetGuildByNameParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "name",
+			In:   "path",
+		}
+		params.Name = packed[key].(string)
+	}
+	return params
+}
+
+func decodeGetGuildByNameParams(args [1]string, argsEscaped bool, r *http.Request) (params GetGuildByNameParams, _ error) {
+	// Decode path: name.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "name",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToString(val)
+				if err != nil {
+					return err
+				}
+
+				params.Name = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "name",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // GetInsomniacsParams is parameters of getInsomniacs operation.
 type GetInsomniacsParams struct {
-	// If true, fetches all pages. If false or omitted, fetches only first page.
+	// If true, fetches every page up to the last one upstream reports. If false or omitted, fetches only
+	// first page.
 	IncludeAll OptBool `json:",omitempty,omitzero"`
 }
 
@@ -218,7 +285,8 @@ func decodeGetInsomniacsParams(args [0]string, argsEscaped bool, r *http.Request
 
 // GetPowerGamersParams is parameters of getPowerGamers operation.
 type GetPowerGamersParams struct {
-	// If true, fetches all pages. If false or omitted, fetches only first page.
+	// If true, fetches every page up to the last one upstream reports. If false or omitted, fetches only
+	// first page.
 	IncludeAll OptBool `json:",omitempty,omitzero"`
 	// Time period for power gamers list.
 	List OptGetPowerGamersList `json:",omitempty,omitzero"`
@@ -92,36 +92,94 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-			case 'g': // Prefix: "guilds/"
+			case 'g': // Prefix: "guilds"
 
-				if l := len("guilds/"); len(elem) >= l && elem[0:l] == "guilds/" {
+				if l := len("guilds"); len(elem) >= l && elem[0:l] == "guilds" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
-				// Param: "guildId"
-				// Leaf parameter, slashes are prohibited
-				idx := strings.IndexByte(elem, '/')
-				if idx >= 0 {
-					break
-				}
-				args[0] = elem
-				elem = ""
-
 				if len(elem) == 0 {
-					// Leaf node.
 					switch r.Method {
 					case "GET":
-						s.handleGetGuildRequest([1]string{
-							args[0],
-						}, elemIsEscaped, w, r)
+						s.handleListGuildsRequest([0]string{}, elemIsEscaped, w, r)
 					default:
 						s.notAllowed(w, r, "GET")
 					}
 
 					return
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "by-name/"
+						origElem := elem
+						if l := len("by-name/"); len(elem) >= l && elem[0:l] == "by-name/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter, slashes are prohibited
+						idx := strings.IndexByte(elem, '/')
+						if idx >= 0 {
+							break
+						}
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetGuildByNameRequest([1]string{
+									args[0],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+
+						elem = origElem
+					}
+					// Param: "guildId"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetGuildRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+
+				}
 
 			case 'h': // Prefix: "health"
 
@@ -337,39 +395,105 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-			case 'g': // Prefix: "guilds/"
+			case 'g': // Prefix: "guilds"
 
-				if l := len("guilds/"); len(elem) >= l && elem[0:l] == "guilds/" {
+				if l := len("guilds"); len(elem) >= l && elem[0:l] == "guilds" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
-				// Param: "guildId"
-				// Leaf parameter, slashes are prohibited
-				idx := strings.IndexByte(elem, '/')
-				if idx >= 0 {
-					break
-				}
-				args[0] = elem
-				elem = ""
-
 				if len(elem) == 0 {
-					// Leaf node.
 					switch method {
 					case "GET":
-						r.name = GetGuildOperation
-						r.summary = "Get guild data from miracle74.com"
-						r.operationID = "getGuild"
+						r.name = ListGuildsOperation
+						r.summary = "List all guilds on miracle74.com"
+						r.operationID = "listGuilds"
 						r.operationGroup = ""
-						r.pathPattern = "/guilds/{guildId}"
+						r.pathPattern = "/guilds"
 						r.args = args
-						r.count = 1
+						r.count = 0
 						return r, true
 					default:
 						return
 					}
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'b': // Prefix: "by-name/"
+						origElem := elem
+						if l := len("by-name/"); len(elem) >= l && elem[0:l] == "by-name/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "name"
+						// Leaf parameter, slashes are prohibited
+						idx := strings.IndexByte(elem, '/')
+						if idx >= 0 {
+							break
+						}
+						args[0] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "GET":
+								r.name = GetGuildByNameOperation
+								r.summary = "Get guild data by guild name"
+								r.operationID = "getGuildByName"
+								r.operationGroup = ""
+								r.pathPattern = "/guilds/by-name/{name}"
+								r.args = args
+								r.count = 1
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					}
+					// Param: "guildId"
+					// Leaf parameter, slashes are prohibited
+					idx := strings.IndexByte(elem, '/')
+					if idx >= 0 {
+						break
+					}
+					args[0] = elem
+					elem = ""
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch method {
+						case "GET":
+							r.name = GetGuildOperation
+							r.summary = "Get guild data from miracle74.com"
+							r.operationID = "getGuild"
+							r.operationGroup = ""
+							r.pathPattern = "/guilds/{guildId}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+
+				}
 
 			case 'h': // Prefix: "health"
 
@@ -8,10 +8,52 @@ import (
 	"github.com/go-faster/errors"
 )
 
+// Ref: #/components/schemas/AccountCharacter
+type AccountCharacter struct {
+	// Character name.
+	Name string `json:"name"`
+	// Game world.
+	World string `json:"world"`
+	// Online, Offline or a note such as deleted.
+	Status string `json:"status"`
+}
+
+// GetName returns the value of Name.
+func (s *AccountCharacter) GetName() string {
+	return s.Name
+}
+
+// GetWorld returns the value of World.
+func (s *AccountCharacter) GetWorld() string {
+	return s.World
+}
+
+// GetStatus returns the value of Status.
+func (s *AccountCharacter) GetStatus() string {
+	return s.Status
+}
+
+// SetName sets the value of Name.
+func (s *AccountCharacter) SetName(val string) {
+	s.Name = val
+}
+
+// SetWorld sets the value of World.
+func (s *AccountCharacter) SetWorld(val string) {
+	s.World = val
+}
+
+// SetStatus sets the value of Status.
+func (s *AccountCharacter) SetStatus(val string) {
+	s.Status = val
+}
+
 // Ref: #/components/schemas/CharacterResponse
 type CharacterResponse struct {
 	// Character name.
 	Name string `json:"name"`
+	// Names the character had before.
+	FormerNames []string `json:"former_names"`
 	// Character sex.
 	Sex string `json:"sex"`
 	// Character vocation.
@@ -32,8 +74,18 @@ type CharacterResponse struct {
 	IsPremium bool `json:"is_premium"`
 	// Country code.
 	Country OptString `json:"country"`
+	// Name of the character this one is married to.
+	MarriedTo OptString `json:"married_to"`
+	House     OptHouse  `json:"house"`
+	// Character comment, with line breaks kept.
+	Comment OptString `json:"comment"`
+	// When the character was created.
+	Created OptDateTime `json:"created"`
 	// Recent character deaths.
 	Deaths []Death `json:"deaths"`
+	// Characters on the same account, including this one, unless the account hides them.
+	AccountCharacters []AccountCharacter `json:"account_characters"`
+	Meta              ResponseMeta       `json:"meta"`
 }
 
 // GetName returns the value of Name.
@@ -41,6 +93,11 @@ func (s *CharacterResponse) GetName() string {
 	return s.Name
 }
 
+// GetFormerNames returns the value of FormerNames.
+func (s *CharacterResponse) GetFormerNames() []string {
+	return s.FormerNames
+}
+
 // GetSex returns the value of Sex.
 func (s *CharacterResponse) GetSex() string {
 	return s.Sex
@@ -91,16 +148,51 @@ func (s *CharacterResponse) GetCountry() OptString {
 	return s.Country
 }
 
+// GetMarriedTo returns the value of MarriedTo.
+func (s *CharacterResponse) GetMarriedTo() OptString {
+	return s.MarriedTo
+}
+
+// GetHouse returns the value of House.
+func (s *CharacterResponse) GetHouse() OptHouse {
+	return s.House
+}
+
+// GetComment returns the value of Comment.
+func (s *CharacterResponse) GetComment() OptString {
+	return s.Comment
+}
+
+// GetCreated returns the value of Created.
+func (s *CharacterResponse) GetCreated() OptDateTime {
+	return s.Created
+}
+
 // GetDeaths returns the value of Deaths.
 func (s *CharacterResponse) GetDeaths() []Death {
 	return s.Deaths
 }
 
+// GetAccountCharacters returns the value of AccountCharacters.
+func (s *CharacterResponse) GetAccountCharacters() []AccountCharacter {
+	return s.AccountCharacters
+}
+
+// GetMeta returns the value of Meta.
+func (s *CharacterResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
 // SetName sets the value of Name.
 func (s *CharacterResponse) SetName(val string) {
 	s.Name = val
 }
 
+// SetFormerNames sets the value of FormerNames.
+func (s *CharacterResponse) SetFormerNames(val []string) {
+	s.FormerNames = val
+}
+
 // SetSex sets the value of Sex.
 func (s *CharacterResponse) SetSex(val string) {
 	s.Sex = val
@@ -151,21 +243,59 @@ func (s *CharacterResponse) SetCountry(val OptString) {
 	s.Country = val
 }
 
+// SetMarriedTo sets the value of MarriedTo.
+func (s *CharacterResponse) SetMarriedTo(val OptString) {
+	s.MarriedTo = val
+}
+
+// SetHouse sets the value of House.
+func (s *CharacterResponse) SetHouse(val OptHouse) {
+	s.House = val
+}
+
+// SetComment sets the value of Comment.
+func (s *CharacterResponse) SetComment(val OptString) {
+	s.Comment = val
+}
+
+// SetCreated sets the value of Created.
+func (s *CharacterResponse) SetCreated(val OptDateTime) {
+	s.Created = val
+}
+
 // SetDeaths sets the value of Deaths.
 func (s *CharacterResponse) SetDeaths(val []Death) {
 	s.Deaths = val
 }
 
+// SetAccountCharacters sets the value of AccountCharacters.
+func (s *CharacterResponse) SetAccountCharacters(val []AccountCharacter) {
+	s.AccountCharacters = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *CharacterResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
 func (*CharacterResponse) getCharacterRes() {}
 
 // Ref: #/components/schemas/Death
 type Death struct {
-	// Death date and time.
+	// Death date and time as shown on the site.
 	Date string `json:"date"`
+	// Death time, in the server's timezone.
+	Time OptDateTime `json:"time"`
 	// Level at time of death.
 	Level int `json:"level"`
-	// What killed the character.
+	// What killed the character, as shown on the site.
 	KilledBy string `json:"killed_by"`
+	// Players and monsters that took part in the kill.
+	Killers []Killer `json:"killers"`
+	// Whether a player took part in the kill.
+	Pvp bool `json:"pvp"`
+	// Whether the site marks the kill as unjustified.
+	Unjustified bool `json:"unjustified"`
 }
 
 // GetDate returns the value of Date.
@@ -173,6 +303,11 @@ func (s *Death) GetDate() string {
 	return s.Date
 }
 
+// GetTime returns the value of Time.
+func (s *Death) GetTime() OptDateTime {
+	return s.Time
+}
+
 // GetLevel returns the value of Level.
 func (s *Death) GetLevel() int {
 	return s.Level
@@ -183,11 +318,31 @@ func (s *Death) GetKilledBy() string {
 	return s.KilledBy
 }
 
+// GetKillers returns the value of Killers.
+func (s *Death) GetKillers() []Killer {
+	return s.Killers
+}
+
+// GetPvp returns the value of Pvp.
+func (s *Death) GetPvp() bool {
+	return s.Pvp
+}
+
+// GetUnjustified returns the value of Unjustified.
+func (s *Death) GetUnjustified() bool {
+	return s.Unjustified
+}
+
 // SetDate sets the value of Date.
 func (s *Death) SetDate(val string) {
 	s.Date = val
 }
 
+// SetTime sets the value of Time.
+func (s *Death) SetTime(val OptDateTime) {
+	s.Time = val
+}
+
 // SetLevel sets the value of Level.
 func (s *Death) SetLevel(val int) {
 	s.Level = val
@@ -198,6 +353,21 @@ func (s *Death) SetKilledBy(val string) {
 	s.KilledBy = val
 }
 
+// SetKillers sets the value of Killers.
+func (s *Death) SetKillers(val []Killer) {
+	s.Killers = val
+}
+
+// SetPvp sets the value of Pvp.
+func (s *Death) SetPvp(val bool) {
+	s.Pvp = val
+}
+
+// SetUnjustified sets the value of Unjustified.
+func (s *Death) SetUnjustified(val bool) {
+	s.Unjustified = val
+}
+
 // Ref: #/components/schemas/ErrorResponse
 type ErrorResponse struct {
 	// Error code.
@@ -226,9 +396,17 @@ func (s *ErrorResponse) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*ErrorResponse) getInsomniacsRes()  {}
-func (*ErrorResponse) getPowerGamersRes() {}
-func (*ErrorResponse) getWhoIsOnlineRes() {}
+type GetCharacterBadGateway ErrorResponse
+
+func (*GetCharacterBadGateway) getCharacterRes() {}
+
+type GetCharacterBadRequest ErrorResponse
+
+func (*GetCharacterBadRequest) getCharacterRes() {}
+
+type GetCharacterGatewayTimeout ErrorResponse
+
+func (*GetCharacterGatewayTimeout) getCharacterRes() {}
 
 type GetCharacterInternalServerError ErrorResponse
 
@@ -238,6 +416,38 @@ type GetCharacterNotFound ErrorResponse
 
 func (*GetCharacterNotFound) getCharacterRes() {}
 
+type GetCharacterServiceUnavailable ErrorResponse
+
+func (*GetCharacterServiceUnavailable) getCharacterRes() {}
+
+type GetGuildBadGateway ErrorResponse
+
+func (*GetGuildBadGateway) getGuildRes() {}
+
+type GetGuildByNameBadGateway ErrorResponse
+
+func (*GetGuildByNameBadGateway) getGuildByNameRes() {}
+
+type GetGuildByNameGatewayTimeout ErrorResponse
+
+func (*GetGuildByNameGatewayTimeout) getGuildByNameRes() {}
+
+type GetGuildByNameInternalServerError ErrorResponse
+
+func (*GetGuildByNameInternalServerError) getGuildByNameRes() {}
+
+type GetGuildByNameNotFound ErrorResponse
+
+func (*GetGuildByNameNotFound) getGuildByNameRes() {}
+
+type GetGuildByNameServiceUnavailable ErrorResponse
+
+func (*GetGuildByNameServiceUnavailable) getGuildByNameRes() {}
+
+type GetGuildGatewayTimeout ErrorResponse
+
+func (*GetGuildGatewayTimeout) getGuildRes() {}
+
 type GetGuildInternalServerError ErrorResponse
 
 func (*GetGuildInternalServerError) getGuildRes() {}
@@ -246,6 +456,38 @@ type GetGuildNotFound ErrorResponse
 
 func (*GetGuildNotFound) getGuildRes() {}
 
+type GetGuildServiceUnavailable ErrorResponse
+
+func (*GetGuildServiceUnavailable) getGuildRes() {}
+
+type GetInsomniacsBadGateway ErrorResponse
+
+func (*GetInsomniacsBadGateway) getInsomniacsRes() {}
+
+type GetInsomniacsGatewayTimeout ErrorResponse
+
+func (*GetInsomniacsGatewayTimeout) getInsomniacsRes() {}
+
+type GetInsomniacsInternalServerError ErrorResponse
+
+func (*GetInsomniacsInternalServerError) getInsomniacsRes() {}
+
+type GetInsomniacsServiceUnavailable ErrorResponse
+
+func (*GetInsomniacsServiceUnavailable) getInsomniacsRes() {}
+
+type GetPowerGamersBadGateway ErrorResponse
+
+func (*GetPowerGamersBadGateway) getPowerGamersRes() {}
+
+type GetPowerGamersGatewayTimeout ErrorResponse
+
+func (*GetPowerGamersGatewayTimeout) getPowerGamersRes() {}
+
+type GetPowerGamersInternalServerError ErrorResponse
+
+func (*GetPowerGamersInternalServerError) getPowerGamersRes() {}
+
 type GetPowerGamersList string
 
 const (
@@ -329,6 +571,10 @@ func (s *GetPowerGamersList) UnmarshalText(data []byte) error {
 	}
 }
 
+type GetPowerGamersServiceUnavailable ErrorResponse
+
+func (*GetPowerGamersServiceUnavailable) getPowerGamersRes() {}
+
 type GetPowerGamersVocation string
 
 const (
@@ -398,6 +644,18 @@ func (s *GetPowerGamersVocation) UnmarshalText(data []byte) error {
 	}
 }
 
+type GetWhoIsOnlineBadGateway ErrorResponse
+
+func (*GetWhoIsOnlineBadGateway) getWhoIsOnlineRes() {}
+
+type GetWhoIsOnlineGatewayTimeout ErrorResponse
+
+func (*GetWhoIsOnlineGatewayTimeout) getWhoIsOnlineRes() {}
+
+type GetWhoIsOnlineInternalServerError ErrorResponse
+
+func (*GetWhoIsOnlineInternalServerError) getWhoIsOnlineRes() {}
+
 type GetWhoIsOnlineOrder string
 
 const (
@@ -446,18 +704,95 @@ func (s *GetWhoIsOnlineOrder) UnmarshalText(data []byte) error {
 	}
 }
 
+type GetWhoIsOnlineServiceUnavailable ErrorResponse
+
+func (*GetWhoIsOnlineServiceUnavailable) getWhoIsOnlineRes() {}
+
+// Ref: #/components/schemas/GuildInvite
+type GuildInvite struct {
+	// Character name.
+	Name string `json:"name"`
+	// Date the character was invited.
+	InvitedAt OptDate `json:"invited_at"`
+}
+
+// GetName returns the value of Name.
+func (s *GuildInvite) GetName() string {
+	return s.Name
+}
+
+// GetInvitedAt returns the value of InvitedAt.
+func (s *GuildInvite) GetInvitedAt() OptDate {
+	return s.InvitedAt
+}
+
+// SetName sets the value of Name.
+func (s *GuildInvite) SetName(val string) {
+	s.Name = val
+}
+
+// SetInvitedAt sets the value of InvitedAt.
+func (s *GuildInvite) SetInvitedAt(val OptDate) {
+	s.InvitedAt = val
+}
+
+// Ref: #/components/schemas/GuildListResponse
+type GuildListResponse struct {
+	// All guilds, in the order the guild list shows them.
+	Guilds []GuildSummary `json:"guilds"`
+	// Total number of guilds.
+	Total int          `json:"total"`
+	Meta  ResponseMeta `json:"meta"`
+}
+
+// GetGuilds returns the value of Guilds.
+func (s *GuildListResponse) GetGuilds() []GuildSummary {
+	return s.Guilds
+}
+
+// GetTotal returns the value of Total.
+func (s *GuildListResponse) GetTotal() int {
+	return s.Total
+}
+
+// GetMeta returns the value of Meta.
+func (s *GuildListResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
+// SetGuilds sets the value of Guilds.
+func (s *GuildListResponse) SetGuilds(val []GuildSummary) {
+	s.Guilds = val
+}
+
+// SetTotal sets the value of Total.
+func (s *GuildListResponse) SetTotal(val int) {
+	s.Total = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *GuildListResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
+func (*GuildListResponse) listGuildsRes() {}
+
 // Ref: #/components/schemas/GuildMember
 type GuildMember struct {
 	// Guild rank title.
 	Rank string `json:"rank"`
 	// Character name.
 	Name string `json:"name"`
+	// Nickname shown next to the member's name.
+	Title OptString `json:"title"`
 	// Character vocation.
 	Vocation string `json:"vocation"`
 	// Character level.
 	Level int `json:"level"`
 	// Player online status.
 	Status string `json:"status"`
+	// Date the member joined the guild.
+	Joined OptDate `json:"joined"`
 }
 
 // GetRank returns the value of Rank.
@@ -470,6 +805,11 @@ func (s *GuildMember) GetName() string {
 	return s.Name
 }
 
+// GetTitle returns the value of Title.
+func (s *GuildMember) GetTitle() OptString {
+	return s.Title
+}
+
 // GetVocation returns the value of Vocation.
 func (s *GuildMember) GetVocation() string {
 	return s.Vocation
@@ -485,6 +825,11 @@ func (s *GuildMember) GetStatus() string {
 	return s.Status
 }
 
+// GetJoined returns the value of Joined.
+func (s *GuildMember) GetJoined() OptDate {
+	return s.Joined
+}
+
 // SetRank sets the value of Rank.
 func (s *GuildMember) SetRank(val string) {
 	s.Rank = val
@@ -495,6 +840,11 @@ func (s *GuildMember) SetName(val string) {
 	s.Name = val
 }
 
+// SetTitle sets the value of Title.
+func (s *GuildMember) SetTitle(val OptString) {
+	s.Title = val
+}
+
 // SetVocation sets the value of Vocation.
 func (s *GuildMember) SetVocation(val string) {
 	s.Vocation = val
@@ -510,14 +860,32 @@ func (s *GuildMember) SetStatus(val string) {
 	s.Status = val
 }
 
+// SetJoined sets the value of Joined.
+func (s *GuildMember) SetJoined(val OptDate) {
+	s.Joined = val
+}
+
 // Ref: #/components/schemas/GuildResponse
 type GuildResponse struct {
 	// Guild ID.
 	GuildID int `json:"guild_id"`
+	// Guild name.
+	Name OptString `json:"name"`
+	// Guild description or message of the day, with line breaks kept.
+	Description OptString `json:"description"`
+	// Date the guild was founded.
+	Founded OptDate `json:"founded"`
+	// Guild logo URL.
+	LogoURL OptString `json:"logo_url"`
+	// Rank titles from highest to lowest.
+	Ranks []string `json:"ranks"`
 	// List of all guild members.
 	Members []GuildMember `json:"members"`
 	// Total number of guild members.
 	Total int `json:"total"`
+	// Characters invited to join the guild.
+	Invites []GuildInvite `json:"invites"`
+	Meta    ResponseMeta  `json:"meta"`
 }
 
 // GetGuildID returns the value of GuildID.
@@ -525,6 +893,31 @@ func (s *GuildResponse) GetGuildID() int {
 	return s.GuildID
 }
 
+// GetName returns the value of Name.
+func (s *GuildResponse) GetName() OptString {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *GuildResponse) GetDescription() OptString {
+	return s.Description
+}
+
+// GetFounded returns the value of Founded.
+func (s *GuildResponse) GetFounded() OptDate {
+	return s.Founded
+}
+
+// GetLogoURL returns the value of LogoURL.
+func (s *GuildResponse) GetLogoURL() OptString {
+	return s.LogoURL
+}
+
+// GetRanks returns the value of Ranks.
+func (s *GuildResponse) GetRanks() []string {
+	return s.Ranks
+}
+
 // GetMembers returns the value of Members.
 func (s *GuildResponse) GetMembers() []GuildMember {
 	return s.Members
@@ -535,11 +928,46 @@ func (s *GuildResponse) GetTotal() int {
 	return s.Total
 }
 
+// GetInvites returns the value of Invites.
+func (s *GuildResponse) GetInvites() []GuildInvite {
+	return s.Invites
+}
+
+// GetMeta returns the value of Meta.
+func (s *GuildResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
 // SetGuildID sets the value of GuildID.
 func (s *GuildResponse) SetGuildID(val int) {
 	s.GuildID = val
 }
 
+// SetName sets the value of Name.
+func (s *GuildResponse) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *GuildResponse) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetFounded sets the value of Founded.
+func (s *GuildResponse) SetFounded(val OptDate) {
+	s.Founded = val
+}
+
+// SetLogoURL sets the value of LogoURL.
+func (s *GuildResponse) SetLogoURL(val OptString) {
+	s.LogoURL = val
+}
+
+// SetRanks sets the value of Ranks.
+func (s *GuildResponse) SetRanks(val []string) {
+	s.Ranks = val
+}
+
 // SetMembers sets the value of Members.
 func (s *GuildResponse) SetMembers(val []GuildMember) {
 	s.Members = val
@@ -550,7 +978,82 @@ func (s *GuildResponse) SetTotal(val int) {
 	s.Total = val
 }
 
-func (*GuildResponse) getGuildRes() {}
+// SetInvites sets the value of Invites.
+func (s *GuildResponse) SetInvites(val []GuildInvite) {
+	s.Invites = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *GuildResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
+func (*GuildResponse) getGuildByNameRes() {}
+func (*GuildResponse) getGuildRes()       {}
+
+// Ref: #/components/schemas/GuildSummary
+type GuildSummary struct {
+	// Guild ID.
+	GuildID int `json:"guild_id"`
+	// Guild name.
+	Name string `json:"name"`
+	// Guild description, with line breaks kept.
+	Description OptString `json:"description"`
+	// Guild logo URL.
+	LogoURL OptString `json:"logo_url"`
+	// Number of guild members.
+	Members int `json:"members"`
+}
+
+// GetGuildID returns the value of GuildID.
+func (s *GuildSummary) GetGuildID() int {
+	return s.GuildID
+}
+
+// GetName returns the value of Name.
+func (s *GuildSummary) GetName() string {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *GuildSummary) GetDescription() OptString {
+	return s.Description
+}
+
+// GetLogoURL returns the value of LogoURL.
+func (s *GuildSummary) GetLogoURL() OptString {
+	return s.LogoURL
+}
+
+// GetMembers returns the value of Members.
+func (s *GuildSummary) GetMembers() int {
+	return s.Members
+}
+
+// SetGuildID sets the value of GuildID.
+func (s *GuildSummary) SetGuildID(val int) {
+	s.GuildID = val
+}
+
+// SetName sets the value of Name.
+func (s *GuildSummary) SetName(val string) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *GuildSummary) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetLogoURL sets the value of LogoURL.
+func (s *GuildSummary) SetLogoURL(val OptString) {
+	s.LogoURL = val
+}
+
+// SetMembers sets the value of Members.
+func (s *GuildSummary) SetMembers(val int) {
+	s.Members = val
+}
 
 // Ref: #/components/schemas/HealthResponse
 type HealthResponse struct {
@@ -630,6 +1133,46 @@ func (s *HealthResponseStatus) UnmarshalText(data []byte) error {
 	}
 }
 
+// Ref: #/components/schemas/House
+type House struct {
+	// House name.
+	Name string `json:"name"`
+	// Town the house is in.
+	Town OptString `json:"town"`
+	// Date the rent is paid until.
+	PaidUntil OptDate `json:"paid_until"`
+}
+
+// GetName returns the value of Name.
+func (s *House) GetName() string {
+	return s.Name
+}
+
+// GetTown returns the value of Town.
+func (s *House) GetTown() OptString {
+	return s.Town
+}
+
+// GetPaidUntil returns the value of PaidUntil.
+func (s *House) GetPaidUntil() OptDate {
+	return s.PaidUntil
+}
+
+// SetName sets the value of Name.
+func (s *House) SetName(val string) {
+	s.Name = val
+}
+
+// SetTown sets the value of Town.
+func (s *House) SetTown(val OptString) {
+	s.Town = val
+}
+
+// SetPaidUntil sets the value of PaidUntil.
+func (s *House) SetPaidUntil(val OptDate) {
+	s.PaidUntil = val
+}
+
 // Ref: #/components/schemas/Insomniac
 type Insomniac struct {
 	// Insomniac rank.
@@ -712,6 +1255,11 @@ type InsomniacsResponse struct {
 	Insomniacs []Insomniac `json:"insomniacs"`
 	// Total number of insomniacs.
 	Total int `json:"total"`
+	// Number of upstream pages fetched to build this list.
+	Pages int `json:"pages"`
+	// Pages that could not be scraped. Their rows are missing from the list.
+	FailedPages []PageFailure `json:"failed_pages"`
+	Meta        ResponseMeta  `json:"meta"`
 }
 
 // GetInsomniacs returns the value of Insomniacs.
@@ -724,6 +1272,21 @@ func (s *InsomniacsResponse) GetTotal() int {
 	return s.Total
 }
 
+// GetPages returns the value of Pages.
+func (s *InsomniacsResponse) GetPages() int {
+	return s.Pages
+}
+
+// GetFailedPages returns the value of FailedPages.
+func (s *InsomniacsResponse) GetFailedPages() []PageFailure {
+	return s.FailedPages
+}
+
+// GetMeta returns the value of Meta.
+func (s *InsomniacsResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
 // SetInsomniacs sets the value of Insomniacs.
 func (s *InsomniacsResponse) SetInsomniacs(val []Insomniac) {
 	s.Insomniacs = val
@@ -734,8 +1297,67 @@ func (s *InsomniacsResponse) SetTotal(val int) {
 	s.Total = val
 }
 
+// SetPages sets the value of Pages.
+func (s *InsomniacsResponse) SetPages(val int) {
+	s.Pages = val
+}
+
+// SetFailedPages sets the value of FailedPages.
+func (s *InsomniacsResponse) SetFailedPages(val []PageFailure) {
+	s.FailedPages = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *InsomniacsResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
 func (*InsomniacsResponse) getInsomniacsRes() {}
 
+// Ref: #/components/schemas/Killer
+type Killer struct {
+	// Character name, or the monster as shown on the site ("an assassin").
+	Name string `json:"name"`
+	// Whether the killer is a player.
+	Player bool `json:"player"`
+}
+
+// GetName returns the value of Name.
+func (s *Killer) GetName() string {
+	return s.Name
+}
+
+// GetPlayer returns the value of Player.
+func (s *Killer) GetPlayer() bool {
+	return s.Player
+}
+
+// SetName sets the value of Name.
+func (s *Killer) SetName(val string) {
+	s.Name = val
+}
+
+// SetPlayer sets the value of Player.
+func (s *Killer) SetPlayer(val bool) {
+	s.Player = val
+}
+
+type ListGuildsBadGateway ErrorResponse
+
+func (*ListGuildsBadGateway) listGuildsRes() {}
+
+type ListGuildsGatewayTimeout ErrorResponse
+
+func (*ListGuildsGatewayTimeout) listGuildsRes() {}
+
+type ListGuildsInternalServerError ErrorResponse
+
+func (*ListGuildsInternalServerError) listGuildsRes() {}
+
+type ListGuildsServiceUnavailable ErrorResponse
+
+func (*ListGuildsServiceUnavailable) listGuildsRes() {}
+
 // Ref: #/components/schemas/OnlinePlayer
 type OnlinePlayer struct {
 	// Character name.
@@ -834,6 +1456,52 @@ func (o OptBool) Or(d bool) bool {
 	return d
 }
 
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptDateTime returns new OptDateTime with value set to v.
 func NewOptDateTime(v time.Time) OptDateTime {
 	return OptDateTime{
@@ -1018,6 +1686,52 @@ func (o OptGetWhoIsOnlineOrder) Or(d GetWhoIsOnlineOrder) GetWhoIsOnlineOrder {
 	return d
 }
 
+// NewOptHouse returns new OptHouse with value set to v.
+func NewOptHouse(v House) OptHouse {
+	return OptHouse{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptHouse is optional House.
+type OptHouse struct {
+	Value House
+	Set   bool
+}
+
+// IsSet returns true if OptHouse was set.
+func (o OptHouse) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptHouse) Reset() {
+	var v House
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptHouse) SetTo(v House) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptHouse) Get() (v House, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptHouse) Or(d House) House {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptInt returns new OptInt with value set to v.
 func NewOptInt(v int) OptInt {
 	return OptInt{
@@ -1110,6 +1824,34 @@ func (o OptString) Or(d string) string {
 	return d
 }
 
+// Ref: #/components/schemas/PageFailure
+type PageFailure struct {
+	// Page number on miracle74.com.
+	Page int `json:"page"`
+	// Why the page could not be scraped.
+	Error string `json:"error"`
+}
+
+// GetPage returns the value of Page.
+func (s *PageFailure) GetPage() int {
+	return s.Page
+}
+
+// GetError returns the value of Error.
+func (s *PageFailure) GetError() string {
+	return s.Error
+}
+
+// SetPage sets the value of Page.
+func (s *PageFailure) SetPage(val int) {
+	s.Page = val
+}
+
+// SetError sets the value of Error.
+func (s *PageFailure) SetError(val string) {
+	s.Error = val
+}
+
 // Ref: #/components/schemas/PowerGamer
 type PowerGamer struct {
 	// Power gamer rank.
@@ -1180,6 +1922,11 @@ type PowerGamersResponse struct {
 	PowerGamers []PowerGamer `json:"power_gamers"`
 	// Total number of power gamers.
 	Total int `json:"total"`
+	// Number of upstream pages fetched to build this list.
+	Pages int `json:"pages"`
+	// Pages that could not be scraped. Their rows are missing from the list.
+	FailedPages []PageFailure `json:"failed_pages"`
+	Meta        ResponseMeta  `json:"meta"`
 }
 
 // GetPowerGamers returns the value of PowerGamers.
@@ -1192,6 +1939,21 @@ func (s *PowerGamersResponse) GetTotal() int {
 	return s.Total
 }
 
+// GetPages returns the value of Pages.
+func (s *PowerGamersResponse) GetPages() int {
+	return s.Pages
+}
+
+// GetFailedPages returns the value of FailedPages.
+func (s *PowerGamersResponse) GetFailedPages() []PageFailure {
+	return s.FailedPages
+}
+
+// GetMeta returns the value of Meta.
+func (s *PowerGamersResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
 // SetPowerGamers sets the value of PowerGamers.
 func (s *PowerGamersResponse) SetPowerGamers(val []PowerGamer) {
 	s.PowerGamers = val
@@ -1202,14 +1964,96 @@ func (s *PowerGamersResponse) SetTotal(val int) {
 	s.Total = val
 }
 
+// SetPages sets the value of Pages.
+func (s *PowerGamersResponse) SetPages(val int) {
+	s.Pages = val
+}
+
+// SetFailedPages sets the value of FailedPages.
+func (s *PowerGamersResponse) SetFailedPages(val []PageFailure) {
+	s.FailedPages = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *PowerGamersResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
 func (*PowerGamersResponse) getPowerGamersRes() {}
 
+// Where the data in a response comes from. The X-Cache (HIT, MISS or STALE) and Age headers tell how
+// it was served and how old it is.
+// Ref: #/components/schemas/ResponseMeta
+type ResponseMeta struct {
+	// When the data was scraped from miracle74.com.
+	ScrapedAt time.Time `json:"scraped_at"`
+	// When the cached data goes stale and is scraped again. Missing if it was not cached.
+	FreshUntil OptDateTime `json:"fresh_until"`
+	// Upstream pages the data was built from.
+	Sources []string `json:"sources"`
+	// Number of upstream pages the data was built from.
+	PagesFetched int `json:"pages_fetched"`
+	// Version of the parser that produced the data.
+	ParserVersion int `json:"parser_version"`
+}
+
+// GetScrapedAt returns the value of ScrapedAt.
+func (s *ResponseMeta) GetScrapedAt() time.Time {
+	return s.ScrapedAt
+}
+
+// GetFreshUntil returns the value of FreshUntil.
+func (s *ResponseMeta) GetFreshUntil() OptDateTime {
+	return s.FreshUntil
+}
+
+// GetSources returns the value of Sources.
+func (s *ResponseMeta) GetSources() []string {
+	return s.Sources
+}
+
+// GetPagesFetched returns the value of PagesFetched.
+func (s *ResponseMeta) GetPagesFetched() int {
+	return s.PagesFetched
+}
+
+// GetParserVersion returns the value of ParserVersion.
+func (s *ResponseMeta) GetParserVersion() int {
+	return s.ParserVersion
+}
+
+// SetScrapedAt sets the value of ScrapedAt.
+func (s *ResponseMeta) SetScrapedAt(val time.Time) {
+	s.ScrapedAt = val
+}
+
+// SetFreshUntil sets the value of FreshUntil.
+func (s *ResponseMeta) SetFreshUntil(val OptDateTime) {
+	s.FreshUntil = val
+}
+
+// SetSources sets the value of Sources.
+func (s *ResponseMeta) SetSources(val []string) {
+	s.Sources = val
+}
+
+// SetPagesFetched sets the value of PagesFetched.
+func (s *ResponseMeta) SetPagesFetched(val int) {
+	s.PagesFetched = val
+}
+
+// SetParserVersion sets the value of ParserVersion.
+func (s *ResponseMeta) SetParserVersion(val int) {
+	s.ParserVersion = val
+}
+
 // Ref: #/components/schemas/WhoIsOnlineResponse
 type WhoIsOnlineResponse struct {
 	// List of all online players.
 	Players []OnlinePlayer `json:"players"`
 	// Total number of online players.
-	Total int `json:"total"`
+	Total int          `json:"total"`
+	Meta  ResponseMeta `json:"meta"`
 }
 
 // GetPlayers returns the value of Players.
@@ -1222,6 +2066,11 @@ func (s *WhoIsOnlineResponse) GetTotal() int {
 	return s.Total
 }
 
+// GetMeta returns the value of Meta.
+func (s *WhoIsOnlineResponse) GetMeta() ResponseMeta {
+	return s.Meta
+}
+
 // SetPlayers sets the value of Players.
 func (s *WhoIsOnlineResponse) SetPlayers(val []OnlinePlayer) {
 	s.Players = val
@@ -1232,4 +2081,9 @@ func (s *WhoIsOnlineResponse) SetTotal(val int) {
 	s.Total = val
 }
 
+// SetMeta sets the value of Meta.
+func (s *WhoIsOnlineResponse) SetMeta(val ResponseMeta) {
+	s.Meta = val
+}
+
 func (*WhoIsOnlineResponse) getWhoIsOnlineRes() {}
@@ -20,6 +20,13 @@ type Handler interface {
 	//
 	// GET /guilds/{guildId}
 	GetGuild(ctx context.Context, params GetGuildParams) (GetGuildRes, error)
+	// GetGuildByName implements getGuildByName operation.
+	//
+	// Resolves the guild name through the guild list, ignoring case, and returns the same data as
+	// getGuild.
+	//
+	// GET /guilds/by-name/{name}
+	GetGuildByName(ctx context.Context, params GetGuildByNameParams) (GetGuildByNameRes, error)
 	// GetHealth implements getHealth operation.
 	//
 	// Returns the current health status of the API.
@@ -46,6 +53,12 @@ type Handler interface {
 	//
 	// GET /whoisonline
 	GetWhoIsOnline(ctx context.Context, params GetWhoIsOnlineParams) (GetWhoIsOnlineRes, error)
+	// ListGuilds implements listGuilds operation.
+	//
+	// Returns every guild on the guild list with its description and member count.
+	//
+	// GET /guilds
+	ListGuilds(ctx context.Context) (ListGuildsRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -31,6 +31,16 @@ func (UnimplementedHandler) GetGuild(ctx context.Context, params GetGuildParams)
 	return r, ht.ErrNotImplemented
 }
 
+// GetGuildByName implements getGuildByName operation.
+//
+// Resolves the guild name through the guild list, ignoring case, and returns the same data as
+// getGuild.
+//
+// GET /guilds/by-name/{name}
+func (UnimplementedHandler) GetGuildByName(ctx context.Context, params GetGuildByNameParams) (r GetGuildByNameRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetHealth implements getHealth operation.
 //
 // Returns the current health status of the API.
@@ -69,3 +79,12 @@ func (UnimplementedHandler) GetPowerGamers(ctx context.Context, params GetPowerG
 func (UnimplementedHandler) GetWhoIsOnline(ctx context.Context, params GetWhoIsOnlineParams) (r GetWhoIsOnlineRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// ListGuilds implements listGuilds operation.
+//
+// Returns every guild on the guild list with its description and member count.
+//
+// GET /guilds
+func (UnimplementedHandler) ListGuilds(ctx context.Context) (r ListGuildsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
@@ -0,0 +1,192 @@
+// Package freshness carries how the data behind a response was served (from
+// cache, fresh from upstream or stale) from the services to the HTTP layer,
+// which reports it in response headers and uses it for conditional requests.
+package freshness
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethaan/miracle74-api/pkg/cache"
+)
+
+type Status string
+
+const (
+	// Hit is a cached entry still within its soft TTL.
+	Hit Status = "HIT"
+	// Miss is data scraped from upstream for this request.
+	Miss Status = "MISS"
+	// Stale is a cached entry past its soft TTL, served while it is
+	// refreshed or because upstream is failing.
+	Stale Status = "STALE"
+)
+
+// Report describes the data a response was built from.
+type Report struct {
+	Status Status
+	Meta   cache.Meta
+}
+
+type reportKey struct{}
+
+// Record stores how the data for the request behind ctx was served. It does
+// nothing when ctx did not pass through Middleware.
+func Record(ctx context.Context, status Status, meta cache.Meta) {
+	if report, ok := ctx.Value(reportKey{}).(*Report); ok {
+		report.Status = status
+		report.Meta = meta
+	}
+}
+
+// FromContext returns the Report recorded for ctx, if any.
+func FromContext(ctx context.Context) (Report, bool) {
+	report, ok := ctx.Value(reportKey{}).(*Report)
+	if !ok || report.Status == "" {
+		return Report{}, false
+	}
+	return *report, true
+}
+
+// Middleware lets handlers further down Record a Report and turns it into
+// response headers: X-Cache with its Status, Age, a Warning for stale data,
+// Cache-Control with how long the data stays fresh, and Last-Modified from
+// when it was scraped. Successful GETs also get an ETag computed from the
+// body and are answered with 304 Not Modified when the client already has
+// it.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := &Report{}
+		ctx := context.WithValue(r.Context(), reportKey{}, report)
+
+		rw := &reportWriter{ResponseWriter: w, request: r, report: report}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+		rw.finish()
+	})
+}
+
+// reportWriter adds headers for the report just before the status line is
+// written, when the handler is done with the services. Bodies it may answer
+// with 304 are held back until the handler returns.
+type reportWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	report      *Report
+	wroteHeader bool
+	body        *bytes.Buffer
+}
+
+func (w *reportWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.wroteHeader = true
+
+	if w.report.Status != "" {
+		setCacheHeaders(w.Header(), *w.report, time.Now())
+		if status == http.StatusOK && w.request.Method == http.MethodGet {
+			w.body = &bytes.Buffer{}
+			return
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *reportWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *reportWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish sends a held back body, or 304 if the request's conditions say the
+// client has it already.
+func (w *reportWriter) finish() {
+	if w.body == nil {
+		return
+	}
+
+	sum := sha256.Sum256(w.body.Bytes())
+	etag := fmt.Sprintf(`"%x"`, sum[:16])
+	w.Header().Set("ETag", etag)
+
+	if notModified(w.request, etag, w.report.Meta.StoredAt) {
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(w.body.Len()))
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes()) // The client went away
+}
+
+// Age returns how long ago, at now, the report's data was scraped.
+func (r Report) Age(now time.Time) time.Duration {
+	if r.Meta.StoredAt.IsZero() || now.Before(r.Meta.StoredAt) {
+		return 0
+	}
+	return now.Sub(r.Meta.StoredAt)
+}
+
+// setCacheHeaders describes how report's data was served (X-Cache) and its
+// freshness in h. Data that was not cached gets no-cache, as it can't be
+// assumed to last.
+func setCacheHeaders(h http.Header, report Report, now time.Time) {
+	meta := report.Meta
+
+	h.Set("X-Cache", string(report.Status))
+	h.Set("Age", strconv.Itoa(int(report.Age(now).Seconds())))
+	if report.Status == Stale {
+		h.Set("Warning", `110 - "Response is Stale"`)
+	}
+
+	switch {
+	case meta.FreshUntil.IsZero():
+		h.Set("Cache-Control", "no-cache")
+	case meta.Stale(now):
+		h.Set("Cache-Control", "max-age=0")
+	default:
+		// Caches subtract Age, so this is the whole time it is fresh for.
+		h.Set("Cache-Control", fmt.Sprintf("max-age=%d", int(meta.FreshUntil.Sub(meta.StoredAt).Seconds())))
+	}
+
+	if !meta.StoredAt.IsZero() {
+		h.Set("Last-Modified", meta.StoredAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when there is
+// none, as RFC 9110 section 13.2.2 orders them.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.IsZero() {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ims)
+}
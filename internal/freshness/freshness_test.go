@@ -0,0 +1,92 @@
+package freshness
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethaan/miracle74-api/pkg/cache"
+)
+
+// serve runs req through Middleware with a handler that records report and
+// writes body.
+func serve(req *http.Request, report Report, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Record(r.Context(), report.Status, report.Meta)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareCacheHeaders(t *testing.T) {
+	storedAt := time.Now().Add(-time.Minute).UTC()
+	report := Report{Status: Hit, Meta: cache.Meta{
+		StoredAt:   storedAt,
+		FreshUntil: storedAt.Add(5 * time.Minute),
+		ExpiresAt:  storedAt.Add(time.Hour),
+	}}
+
+	rec := serve(httptest.NewRequest(http.MethodGet, "/characters/Oten", nil), report, `{"name":"Oten"}`)
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"name":"Oten"}` {
+		t.Fatalf("got %d %q", rec.Code, rec.Body)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "max-age=300" {
+		t.Errorf("Cache-Control = %q, want max-age=300", cc)
+	}
+	if age := rec.Header().Get("Age"); age != "60" && age != "61" {
+		t.Errorf("Age = %q, want about a minute", age)
+	}
+	if xc := rec.Header().Get("X-Cache"); xc != "HIT" {
+		t.Errorf("X-Cache = %q, want HIT", xc)
+	}
+	if lm := rec.Header().Get("Last-Modified"); lm != storedAt.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q", lm)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/characters/Oten", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	if rec := serve(req, report, `{"name":"Oten"}`); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("If-None-Match: got %d %q, want empty 304", rec.Code, rec.Body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/characters/Oten", nil)
+	req.Header.Set("If-None-Match", etag)
+	if rec := serve(req, report, `{"name":"Oten","level":82}`); rec.Code != http.StatusOK {
+		t.Errorf("If-None-Match after change: got %d, want 200", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/characters/Oten", nil)
+	req.Header.Set("If-Modified-Since", storedAt.Format(http.TimeFormat))
+	if rec := serve(req, report, `{"name":"Oten"}`); rec.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since: got %d, want 304", rec.Code)
+	}
+}
+
+func TestMiddlewareStale(t *testing.T) {
+	storedAt := time.Now().Add(-time.Hour).UTC()
+	report := Report{Status: Stale, Meta: cache.Meta{StoredAt: storedAt, FreshUntil: storedAt.Add(time.Minute)}}
+
+	rec := serve(httptest.NewRequest(http.MethodGet, "/whoisonline", nil), report, `[]`)
+	if cc := rec.Header().Get("Cache-Control"); cc != "max-age=0" {
+		t.Errorf("Cache-Control = %q, want max-age=0", cc)
+	}
+	if rec.Header().Get("Warning") == "" {
+		t.Error("no Warning header on stale response")
+	}
+}
+
+func TestMiddlewareWithoutReport(t *testing.T) {
+	rec := serve(httptest.NewRequest(http.MethodGet, "/health", nil), Report{}, `{}`)
+	for _, header := range []string{"Cache-Control", "ETag", "Last-Modified", "X-Cache", "Age"} {
+		if v := rec.Header().Get(header); v != "" {
+			t.Errorf("%s = %q, want none", header, v)
+		}
+	}
+}
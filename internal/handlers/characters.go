@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethaan/miracle74-api/internal/api"
 )
@@ -9,25 +10,44 @@ import (
 func (h *Handler) GetCharacter(ctx context.Context, params api.GetCharacterParams) (api.GetCharacterRes, error) {
 	character, err := h.characterService.GetCharacter(ctx, params.Name)
 	if err != nil {
-		return &api.GetCharacterInternalServerError{
-			Error:   "fetch_failed",
-			Message: err.Error(),
-		}, nil
+		return characterError(err), nil
 	}
 
 	var deaths []api.Death
 	for _, d := range character.Deaths {
-		deaths = append(deaths, api.Death{
-			Date:     d.Date,
-			Level:    d.Level,
-			KilledBy: d.KilledBy,
+		killers := make([]api.Killer, 0, len(d.Killers))
+		for _, k := range d.Killers {
+			killers = append(killers, api.Killer{Name: k.Name, Player: k.Player})
+		}
+
+		death := api.Death{
+			Date:        d.Date,
+			Level:       d.Level,
+			KilledBy:    d.KilledBy,
+			Killers:     killers,
+			Pvp:         d.PvP,
+			Unjustified: d.Unjustified,
+		}
+		if d.Time != nil {
+			death.Time.SetTo(*d.Time)
+		}
+		deaths = append(deaths, death)
+	}
+
+	var accountCharacters []api.AccountCharacter
+	for _, c := range character.AccountCharacters {
+		accountCharacters = append(accountCharacters, api.AccountCharacter{
+			Name:   c.Name,
+			World:  c.World,
+			Status: c.Status,
 		})
 	}
 
 	response := &api.CharacterResponse{
-		Name:      character.Name,
-		Sex:       character.Sex,
-		IsPremium: character.IsPremium,
+		Name:        character.Name,
+		FormerNames: character.FormerNames,
+		Sex:         character.Sex,
+		IsPremium:   character.IsPremium,
 	}
 
 	if character.Vocation != "" {
@@ -54,8 +74,47 @@ func (h *Handler) GetCharacter(ctx context.Context, params api.GetCharacterParam
 	if character.Country != "" {
 		response.Country.SetTo(character.Country)
 	}
+	if character.MarriedTo != "" {
+		response.MarriedTo.SetTo(character.MarriedTo)
+	}
+	if character.House != nil {
+		house := api.House{Name: character.House.Name}
+		if character.House.Town != "" {
+			house.Town.SetTo(character.House.Town)
+		}
+		if character.House.PaidUntil != nil {
+			house.PaidUntil.SetTo(*character.House.PaidUntil)
+		}
+		response.House.SetTo(house)
+	}
+	if character.Comment != "" {
+		response.Comment.SetTo(character.Comment)
+	}
+	if character.Created != nil {
+		response.Created.SetTo(*character.Created)
+	}
 
 	response.Deaths = deaths
+	response.AccountCharacters = accountCharacters
+	response.Meta = responseMeta(ctx)
 
 	return response, nil
 }
+
+func characterError(err error) api.GetCharacterRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusBadRequest:
+		return (*api.GetCharacterBadRequest)(&body)
+	case http.StatusNotFound:
+		return (*api.GetCharacterNotFound)(&body)
+	case http.StatusBadGateway:
+		return (*api.GetCharacterBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetCharacterServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetCharacterGatewayTimeout)(&body)
+	default:
+		return (*api.GetCharacterInternalServerError)(&body)
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ethaan/miracle74-api/internal/api"
+	"github.com/ethaan/miracle74-api/internal/names"
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+// classifyError maps a service error to the HTTP status and error body it
+// should be reported with. Each handler converts the pair into its own
+// ogen response variant.
+func classifyError(err error) (int, api.ErrorResponse) {
+	switch {
+	case errors.Is(err, names.ErrInvalid):
+		return http.StatusBadRequest, api.ErrorResponse{Error: "invalid_name", Message: err.Error()}
+	case errors.Is(err, miracle74.ErrCharacterNotFound):
+		return http.StatusNotFound, api.ErrorResponse{Error: "not_found", Message: "Character not found"}
+	case errors.Is(err, miracle74.ErrGuildNotFound):
+		return http.StatusNotFound, api.ErrorResponse{Error: "not_found", Message: "Guild not found"}
+	case errors.Is(err, miracle74.ErrRateLimited):
+		return http.StatusServiceUnavailable, api.ErrorResponse{Error: "rate_limited", Message: err.Error()}
+	case errors.Is(err, miracle74.ErrUpstreamTimeout):
+		return http.StatusGatewayTimeout, api.ErrorResponse{Error: "upstream_timeout", Message: err.Error()}
+	case errors.Is(err, miracle74.ErrUpstreamUnavailable):
+		return http.StatusBadGateway, api.ErrorResponse{Error: "upstream_unavailable", Message: err.Error()}
+	case errors.Is(err, miracle74.ErrParse):
+		return http.StatusInternalServerError, api.ErrorResponse{Error: "parse_failed", Message: err.Error()}
+	default:
+		return http.StatusInternalServerError, api.ErrorResponse{Error: "fetch_failed", Message: err.Error()}
+	}
+}
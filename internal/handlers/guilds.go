@@ -2,35 +2,154 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethaan/miracle74-api/internal/api"
+	"github.com/ethaan/miracle74-api/internal/types"
 )
 
 func (h *Handler) GetGuild(ctx context.Context, params api.GetGuildParams) (api.GetGuildRes, error) {
 	guild, err := h.guildService.GetGuild(ctx, params.GuildId)
 	if err != nil {
-		return &api.GetGuildInternalServerError{
-			Error:   "fetch_failed",
-			Message: err.Error(),
-		}, nil
+		return guildError(err), nil
 	}
 
+	return guildResponse(ctx, guild), nil
+}
+
+func (h *Handler) GetGuildByName(ctx context.Context, params api.GetGuildByNameParams) (api.GetGuildByNameRes, error) {
+	guild, err := h.guildService.GetGuildByName(ctx, params.Name)
+	if err != nil {
+		return guildByNameError(err), nil
+	}
+
+	return guildResponse(ctx, guild), nil
+}
+
+func (h *Handler) ListGuilds(ctx context.Context) (api.ListGuildsRes, error) {
+	guilds, err := h.guildService.ListGuilds(ctx)
+	if err != nil {
+		return guildListError(err), nil
+	}
+
+	summaries := make([]api.GuildSummary, 0, len(guilds))
+	for _, g := range guilds {
+		summary := api.GuildSummary{
+			GuildID: g.GuildID,
+			Name:    g.Name,
+			Members: g.Members,
+		}
+		if g.Description != "" {
+			summary.Description.SetTo(g.Description)
+		}
+		if g.LogoURL != "" {
+			summary.LogoURL.SetTo(g.LogoURL)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return &api.GuildListResponse{
+		Guilds: summaries,
+		Total:  len(summaries),
+		Meta:   responseMeta(ctx),
+	}, nil
+}
+
+func guildResponse(ctx context.Context, guild *types.Guild) *api.GuildResponse {
 	var members []api.GuildMember
 	for _, m := range guild.Members {
-		members = append(members, api.GuildMember{
+		member := api.GuildMember{
 			Rank:     m.Rank,
 			Name:     m.Name,
 			Vocation: m.Vocation,
 			Level:    m.Level,
 			Status:   m.Status,
-		})
+		}
+		if m.Title != "" {
+			member.Title.SetTo(m.Title)
+		}
+		if m.Joined != nil {
+			member.Joined.SetTo(*m.Joined)
+		}
+		members = append(members, member)
+	}
+
+	var invites []api.GuildInvite
+	for _, inv := range guild.Invites {
+		invite := api.GuildInvite{Name: inv.Name}
+		if inv.InvitedAt != nil {
+			invite.InvitedAt.SetTo(*inv.InvitedAt)
+		}
+		invites = append(invites, invite)
 	}
 
 	response := &api.GuildResponse{
 		GuildID: guild.GuildID,
+		Ranks:   guild.Ranks,
 		Members: members,
 		Total:   len(members),
+		Invites: invites,
+		Meta:    responseMeta(ctx),
+	}
+
+	if guild.Name != "" {
+		response.Name.SetTo(guild.Name)
+	}
+	if guild.Description != "" {
+		response.Description.SetTo(guild.Description)
 	}
+	if guild.Founded != nil {
+		response.Founded.SetTo(*guild.Founded)
+	}
+	if guild.LogoURL != "" {
+		response.LogoURL.SetTo(guild.LogoURL)
+	}
+
+	return response
+}
 
-	return response, nil
+func guildError(err error) api.GetGuildRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusNotFound:
+		return (*api.GetGuildNotFound)(&body)
+	case http.StatusBadGateway:
+		return (*api.GetGuildBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetGuildServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetGuildGatewayTimeout)(&body)
+	default:
+		return (*api.GetGuildInternalServerError)(&body)
+	}
+}
+
+func guildByNameError(err error) api.GetGuildByNameRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusNotFound:
+		return (*api.GetGuildByNameNotFound)(&body)
+	case http.StatusBadGateway:
+		return (*api.GetGuildByNameBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetGuildByNameServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetGuildByNameGatewayTimeout)(&body)
+	default:
+		return (*api.GetGuildByNameInternalServerError)(&body)
+	}
+}
+
+func guildListError(err error) api.ListGuildsRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusBadGateway:
+		return (*api.ListGuildsBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.ListGuildsServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.ListGuildsGatewayTimeout)(&body)
+	default:
+		return (*api.ListGuildsInternalServerError)(&body)
+	}
 }
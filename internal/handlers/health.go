@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethaan/miracle74-api/internal/api"
 	"github.com/ethaan/miracle74-api/internal/services"
+	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
 type Handler struct {
@@ -14,21 +15,29 @@ type Handler struct {
 	insomniacsService  *services.InsomniacsService
 	guildService       *services.GuildService
 	whoIsOnlineService *services.WhoIsOnlineService
+	cache              cache.Cache
 }
 
-func NewHandler(characterService *services.CharacterService, powerGamersService *services.PowerGamersService, insomniacsService *services.InsomniacsService, guildService *services.GuildService, whoIsOnlineService *services.WhoIsOnlineService) *Handler {
+func NewHandler(characterService *services.CharacterService, powerGamersService *services.PowerGamersService, insomniacsService *services.InsomniacsService, guildService *services.GuildService, whoIsOnlineService *services.WhoIsOnlineService, cacheClient cache.Cache) *Handler {
 	return &Handler{
 		characterService:   characterService,
 		powerGamersService: powerGamersService,
 		insomniacsService:  insomniacsService,
 		guildService:       guildService,
 		whoIsOnlineService: whoIsOnlineService,
+		cache:              cacheClient,
 	}
 }
 
 func (h *Handler) GetHealth(ctx context.Context) (*api.HealthResponse, error) {
+	// Degraded while caching in memory only: each instance scrapes on its own.
+	status := api.HealthResponseStatusHealthy
+	if h.cache.Degraded() {
+		status = api.HealthResponseStatusDegraded
+	}
+
 	return &api.HealthResponse{
-		Status:    api.HealthResponseStatusHealthy,
+		Status:    status,
 		Timestamp: time.Now().UTC(),
 		Version:   "0.1.0",
 	}, nil
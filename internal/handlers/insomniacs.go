@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethaan/miracle74-api/internal/api"
 )
@@ -11,14 +12,11 @@ func (h *Handler) GetInsomniacs(ctx context.Context, params api.GetInsomniacsPar
 
 	insomniacs, err := h.insomniacsService.GetInsomniacs(ctx, includeAll)
 	if err != nil {
-		return &api.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: err.Error(),
-		}, nil
+		return insomniacsError(err), nil
 	}
 
 	var apiInsomniacs []api.Insomniac
-	for _, ins := range insomniacs {
+	for _, ins := range insomniacs.Insomniacs {
 		apiInsomniac := api.Insomniac{
 			Rank:       ins.Rank,
 			Name:       ins.Name,
@@ -33,7 +31,24 @@ func (h *Handler) GetInsomniacs(ctx context.Context, params api.GetInsomniacsPar
 	}
 
 	return &api.InsomniacsResponse{
-		Insomniacs: apiInsomniacs,
-		Total:      len(apiInsomniacs),
+		Insomniacs:  apiInsomniacs,
+		Total:       len(apiInsomniacs),
+		Pages:       insomniacs.Pages,
+		FailedPages: pageFailures(insomniacs.FailedPages),
+		Meta:        responseMeta(ctx),
 	}, nil
 }
+
+func insomniacsError(err error) api.GetInsomniacsRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusBadGateway:
+		return (*api.GetInsomniacsBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetInsomniacsServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetInsomniacsGatewayTimeout)(&body)
+	default:
+		return (*api.GetInsomniacsInternalServerError)(&body)
+	}
+}
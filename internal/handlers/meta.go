@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/ethaan/miracle74-api/internal/api"
+	"github.com/ethaan/miracle74-api/internal/freshness"
+)
+
+// responseMeta describes where the data behind the response to ctx's
+// request came from, as the services recorded it.
+func responseMeta(ctx context.Context) api.ResponseMeta {
+	report, _ := freshness.FromContext(ctx)
+
+	meta := api.ResponseMeta{
+		ScrapedAt:     report.Meta.StoredAt,
+		Sources:       report.Meta.Sources,
+		PagesFetched:  len(report.Meta.Sources),
+		ParserVersion: report.Meta.Version,
+	}
+	if meta.Sources == nil {
+		meta.Sources = []string{}
+	}
+	if !report.Meta.FreshUntil.IsZero() {
+		meta.FreshUntil.SetTo(report.Meta.FreshUntil)
+	}
+	return meta
+}
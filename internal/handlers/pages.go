@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"github.com/ethaan/miracle74-api/internal/api"
+	"github.com/ethaan/miracle74-api/internal/types"
+)
+
+func pageFailures(failures []types.PageFailure) []api.PageFailure {
+	var apiFailures []api.PageFailure
+	for _, f := range failures {
+		apiFailures = append(apiFailures, api.PageFailure{
+			Page:  f.Page,
+			Error: f.Error,
+		})
+	}
+	return apiFailures
+}
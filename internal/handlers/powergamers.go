@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethaan/miracle74-api/internal/api"
 )
@@ -13,14 +14,11 @@ func (h *Handler) GetPowerGamers(ctx context.Context, params api.GetPowerGamersP
 
 	powerGamers, err := h.powerGamersService.GetPowerGamers(ctx, includeAll, list, vocation)
 	if err != nil {
-		return &api.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: err.Error(),
-		}, nil
+		return powerGamersError(err), nil
 	}
 
 	var apiPowerGamers []api.PowerGamer
-	for _, pg := range powerGamers {
+	for _, pg := range powerGamers.PowerGamers {
 		apiPowerGamers = append(apiPowerGamers, api.PowerGamer{
 			Rank:     pg.Rank,
 			Name:     pg.Name,
@@ -33,5 +31,22 @@ func (h *Handler) GetPowerGamers(ctx context.Context, params api.GetPowerGamersP
 	return &api.PowerGamersResponse{
 		PowerGamers: apiPowerGamers,
 		Total:       len(apiPowerGamers),
+		Pages:       powerGamers.Pages,
+		FailedPages: pageFailures(powerGamers.FailedPages),
+		Meta:        responseMeta(ctx),
 	}, nil
 }
+
+func powerGamersError(err error) api.GetPowerGamersRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusBadGateway:
+		return (*api.GetPowerGamersBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetPowerGamersServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetPowerGamersGatewayTimeout)(&body)
+	default:
+		return (*api.GetPowerGamersInternalServerError)(&body)
+	}
+}
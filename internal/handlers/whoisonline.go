@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/ethaan/miracle74-api/internal/api"
 )
@@ -11,10 +12,7 @@ func (h *Handler) GetWhoIsOnline(ctx context.Context, params api.GetWhoIsOnlineP
 
 	onlinePlayers, err := h.whoIsOnlineService.GetWhoIsOnline(ctx, order)
 	if err != nil {
-		return &api.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: err.Error(),
-		}, nil
+		return whoIsOnlineError(err), nil
 	}
 
 	var apiOnlinePlayers []api.OnlinePlayer
@@ -30,5 +28,20 @@ func (h *Handler) GetWhoIsOnline(ctx context.Context, params api.GetWhoIsOnlineP
 	return &api.WhoIsOnlineResponse{
 		Players: apiOnlinePlayers,
 		Total:   len(apiOnlinePlayers),
+		Meta:    responseMeta(ctx),
 	}, nil
 }
+
+func whoIsOnlineError(err error) api.GetWhoIsOnlineRes {
+	status, body := classifyError(err)
+	switch status {
+	case http.StatusBadGateway:
+		return (*api.GetWhoIsOnlineBadGateway)(&body)
+	case http.StatusServiceUnavailable:
+		return (*api.GetWhoIsOnlineServiceUnavailable)(&body)
+	case http.StatusGatewayTimeout:
+		return (*api.GetWhoIsOnlineGatewayTimeout)(&body)
+	default:
+		return (*api.GetWhoIsOnlineInternalServerError)(&body)
+	}
+}
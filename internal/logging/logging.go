@@ -0,0 +1,136 @@
+// Package logging sets up the process-wide structured logger and carries a
+// per-request ID through contexts so every log line of a request can be
+// correlated.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ogen-go/ogen/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// New returns a logger writing to w. format "json" selects JSON lines,
+// anything else human-readable text. level is one of debug, info, warn or
+// error and defaults to info.
+func New(w io.Writer, format, level string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return slog.New(contextHandler{handler})
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// contextHandler adds the request ID and trace ID stored in the record's
+// context, if any, so log lines can be matched to their trace.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// WithRequestID returns a copy of ctx carrying id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDHeaders are checked in order for an ID assigned upstream of us.
+var requestIDHeaders = []string{"X-Request-Id", "Fly-Request-Id"}
+
+// Middleware is an ogen middleware that tags the request context with a
+// request ID (reusing one set by a proxy when present) and logs every
+// operation with its outcome and duration.
+func Middleware(logger *slog.Logger) middleware.Middleware {
+	return func(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+		var id string
+		for _, header := range requestIDHeaders {
+			if id = req.Raw.Header.Get(header); id != "" {
+				break
+			}
+		}
+		if id == "" {
+			id = newRequestID()
+		}
+		req.SetContext(WithRequestID(req.Context, id))
+
+		start := time.Now()
+		resp, err := next(req)
+
+		attrs := []any{
+			"operation", req.OperationName,
+			"path", req.Raw.URL.Path,
+			"duration", time.Since(start),
+		}
+		if err != nil {
+			logger.ErrorContext(req.Context, "request failed", append(attrs, "error", err)...)
+		} else {
+			logger.InfoContext(req.Context, "request served", append(attrs, "response", responseName(resp.Type))...)
+		}
+
+		return resp, err
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// responseName turns an ogen response type such as *api.GetCharacterNotFound
+// into "GetCharacterNotFound".
+func responseName(v any) string {
+	if v == nil {
+		return ""
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", v), "*api.")
+}
@@ -0,0 +1,50 @@
+// Package names validates character names taken from requests and derives
+// the form they are cached under, so "Oten", "oten" and " Oten " are one
+// character to the repos and services.
+package names
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Character names on miracle74.com are letters, with words separated by
+// single spaces, apostrophes or hyphens.
+const (
+	MinLength = 2
+	MaxLength = 30
+)
+
+// ErrInvalid is returned by Normalize for names no character can have.
+var ErrInvalid = errors.New("invalid character name")
+
+// Normalize trims name and collapses runs of whitespace into single spaces,
+// keeping its case, and checks that a character could be called that.
+func Normalize(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+
+	if n := len(name); n < MinLength || n > MaxLength {
+		return "", fmt.Errorf("%w: must be %d to %d characters long", ErrInvalid, MinLength, MaxLength)
+	}
+	for _, r := range name {
+		if !isLetter(r) && r != ' ' && r != '\'' && r != '-' {
+			return "", fmt.Errorf("%w: %q is not allowed", ErrInvalid, r)
+		}
+	}
+	if !isLetter(rune(name[0])) {
+		return "", fmt.Errorf("%w: must start with a letter", ErrInvalid)
+	}
+
+	return name, nil
+}
+
+// Key returns the case-folded form of a normalized name, which identifies
+// the character in cache keys.
+func Key(name string) string {
+	return strings.ToLower(name)
+}
+
+func isLetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
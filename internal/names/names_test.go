@@ -0,0 +1,45 @@
+package names
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Oten", want: "Oten"},
+		{name: "  Oten\t", want: "Oten"},
+		{name: "Sir  Oten   the Brave", want: "Sir Oten the Brave"},
+		{name: "Ka'ra Mar-Vel", want: "Ka'ra Mar-Vel"},
+		{name: "", wantErr: true},
+		{name: "O", wantErr: true},
+		{name: "Oten123", wantErr: true},
+		{name: "Oten/../admin", wantErr: true},
+		{name: "-Oten", wantErr: true},
+		{name: "Öten", wantErr: true},
+		{name: "Abcdefghijklmnopqrstuvwxyzabcde", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Normalize(tt.name)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Normalize(%q) error = %v, want ErrInvalid", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	if Key("Sir Oten") != Key("sir oten") {
+		t.Errorf("Key() differs by case: %q, %q", Key("Sir Oten"), Key("sir oten"))
+	}
+}
@@ -2,43 +2,109 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ethaan/miracle74-api/internal/names"
 	"github.com/ethaan/miracle74-api/internal/types"
 	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
+// CharacterTTL keeps characters fresh for five minutes and serves them stale
+// for up to an hour while upstream is refreshed or unavailable.
+var CharacterTTL = cache.TTL{Soft: 5 * time.Minute, Hard: time.Hour}
+
 type CharacterRepo struct {
-	cache *cache.Client
+	cache cache.Cache
 }
 
-func NewCharacterRepo(cacheClient *cache.Client) *CharacterRepo {
+func NewCharacterRepo(cacheClient cache.Cache) *CharacterRepo {
 	return &CharacterRepo{
 		cache: cacheClient,
 	}
 }
 
-func (r *CharacterRepo) Get(ctx context.Context, name string) (*types.Character, error) {
-	key := r.buildKey(name)
+// Get returns the character called name, following its alias if the entry
+// is stored under the name the site spells it with, see SetAlias.
+func (r *CharacterRepo) Get(ctx context.Context, name string) (*types.Character, cache.Meta, error) {
+	character, meta, err := r.get(ctx, name)
+	if !errors.Is(err, cache.ErrCacheMiss) {
+		return character, meta, err
+	}
+
+	var canonical string
+	if _, err := r.cache.GetEntry(ctx, r.aliasKey(name), &canonical); err != nil {
+		return nil, cache.Meta{}, err
+	}
+	return r.get(ctx, canonical)
+}
+
+func (r *CharacterRepo) get(ctx context.Context, name string) (*types.Character, cache.Meta, error) {
+	key := r.BuildKey(name)
 
 	var character types.Character
-	if err := r.cache.Get(ctx, key, &character); err != nil {
-		return nil, err
+	meta, err := r.cache.GetEntry(ctx, key, &character)
+	if err != nil {
+		return nil, cache.Meta{}, err
 	}
 
-	return &character, nil
+	return &character, meta, nil
 }
 
-func (r *CharacterRepo) Set(ctx context.Context, name string, character *types.Character) error {
-	key := r.buildKey(name)
-	return r.cache.Set(ctx, key, character)
+func (r *CharacterRepo) Set(ctx context.Context, name string, character *types.Character, sources []string) (cache.Meta, error) {
+	key := r.BuildKey(name)
+	return r.cache.SetEntry(ctx, key, character, CharacterTTL, sources...)
 }
 
+// Delete drops the entry of the character called name and its alias.
 func (r *CharacterRepo) Delete(ctx context.Context, name string) error {
-	key := r.buildKey(name)
-	return r.cache.Delete(ctx, key)
+	return r.cache.Delete(ctx, r.BuildKey(name), r.aliasKey(name))
+}
+
+// SetAlias makes Get for name answer with the entry of canonical, for names
+// upstream matches but spells differently. An entry stored under name itself
+// is dropped, so there is only ever one copy to refresh.
+func (r *CharacterRepo) SetAlias(ctx context.Context, name, canonical string) error {
+	if err := r.cache.Delete(ctx, r.BuildKey(name)); err != nil {
+		return err
+	}
+	_, err := r.cache.SetEntry(ctx, r.aliasKey(name), canonical, CharacterTTL)
+	return err
+}
+
+// GetNotFound returns cache.ErrCacheMiss unless the character was recently
+// found not to exist, see NotFoundTTL.
+func (r *CharacterRepo) GetNotFound(ctx context.Context, name string) (cache.Meta, error) {
+	return getNotFound(ctx, r.cache, r.BuildKey(name))
+}
+
+// SetNotFound remembers that the character doesn't exist for NotFoundTTL.
+func (r *CharacterRepo) SetNotFound(ctx context.Context, name string) (cache.Meta, error) {
+	return setNotFound(ctx, r.cache, r.BuildKey(name))
+}
+
+// DeleteNotFound forgets that the characters didn't exist, all in one cache
+// round trip.
+func (r *CharacterRepo) DeleteNotFound(ctx context.Context, names ...string) error {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = r.BuildKey(name)
+	}
+	return deleteNotFound(ctx, r.cache, keys...)
+}
+
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *CharacterRepo) Lock(ctx context.Context, name string) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(name), ScrapeLockTTL)
+}
+
+func (r *CharacterRepo) aliasKey(name string) string {
+	return "alias:" + r.BuildKey(name)
 }
 
-func (r *CharacterRepo) buildKey(name string) string {
-	return fmt.Sprintf("character:%s", name)
+// BuildKey returns the key of the character called name, which must be
+// normalized, see names.Normalize. Names differing only in case share it.
+func (r *CharacterRepo) BuildKey(name string) string {
+	return fmt.Sprintf("character:%s", names.Key(name))
 }
@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethaan/miracle74-api/internal/types"
+	"github.com/ethaan/miracle74-api/pkg/cache"
+)
+
+// GuildDirectoryTTL keeps the guild list, which is also how guild names are
+// resolved to IDs, fresh for an hour and serves it for up to a day: guilds
+// are rarely founded or renamed.
+var GuildDirectoryTTL = cache.TTL{Soft: time.Hour, Hard: 24 * time.Hour}
+
+type GuildDirectoryRepo struct {
+	cache cache.Cache
+}
+
+func NewGuildDirectoryRepo(cacheClient cache.Cache) *GuildDirectoryRepo {
+	return &GuildDirectoryRepo{
+		cache: cacheClient,
+	}
+}
+
+func (r *GuildDirectoryRepo) Get(ctx context.Context) ([]types.GuildSummary, cache.Meta, error) {
+	var guilds []types.GuildSummary
+	meta, err := r.cache.GetEntry(ctx, r.BuildKey(), &guilds)
+	if err != nil {
+		return nil, cache.Meta{}, err
+	}
+
+	return guilds, meta, nil
+}
+
+func (r *GuildDirectoryRepo) Set(ctx context.Context, guilds []types.GuildSummary, sources []string) (cache.Meta, error) {
+	return r.cache.SetEntry(ctx, r.BuildKey(), guilds, GuildDirectoryTTL, sources...)
+}
+
+func (r *GuildDirectoryRepo) Delete(ctx context.Context) error {
+	return r.cache.Delete(ctx, r.BuildKey())
+}
+
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *GuildDirectoryRepo) Lock(ctx context.Context) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(), ScrapeLockTTL)
+}
+
+func (r *GuildDirectoryRepo) BuildKey() string {
+	return "guilddirectory"
+}
@@ -3,42 +3,69 @@ package repo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethaan/miracle74-api/internal/types"
 	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
+// GuildTTL keeps guilds fresh for five minutes and serves them stale for up
+// to an hour while upstream is refreshed or unavailable.
+var GuildTTL = cache.TTL{Soft: 5 * time.Minute, Hard: time.Hour}
+
 type GuildRepo struct {
-	cache *cache.Client
+	cache cache.Cache
 }
 
-func NewGuildRepo(cacheClient *cache.Client) *GuildRepo {
+func NewGuildRepo(cacheClient cache.Cache) *GuildRepo {
 	return &GuildRepo{
 		cache: cacheClient,
 	}
 }
 
-func (r *GuildRepo) Get(ctx context.Context, guildID int) (*types.Guild, error) {
-	key := r.buildKey(guildID)
+func (r *GuildRepo) Get(ctx context.Context, guildID int) (*types.Guild, cache.Meta, error) {
+	key := r.BuildKey(guildID)
 
 	var guild types.Guild
-	if err := r.cache.Get(ctx, key, &guild); err != nil {
-		return nil, err
+	meta, err := r.cache.GetEntry(ctx, key, &guild)
+	if err != nil {
+		return nil, cache.Meta{}, err
 	}
 
-	return &guild, nil
+	return &guild, meta, nil
 }
 
-func (r *GuildRepo) Set(ctx context.Context, guildID int, guild *types.Guild) error {
-	key := r.buildKey(guildID)
-	return r.cache.Set(ctx, key, guild)
+func (r *GuildRepo) Set(ctx context.Context, guildID int, guild *types.Guild, sources []string) (cache.Meta, error) {
+	key := r.BuildKey(guildID)
+	return r.cache.SetEntry(ctx, key, guild, GuildTTL, sources...)
 }
 
 func (r *GuildRepo) Delete(ctx context.Context, guildID int) error {
-	key := r.buildKey(guildID)
+	key := r.BuildKey(guildID)
 	return r.cache.Delete(ctx, key)
 }
 
-func (r *GuildRepo) buildKey(guildID int) string {
+// GetNotFound returns cache.ErrCacheMiss unless the guild was recently
+// found not to exist, see NotFoundTTL.
+func (r *GuildRepo) GetNotFound(ctx context.Context, guildID int) (cache.Meta, error) {
+	return getNotFound(ctx, r.cache, r.BuildKey(guildID))
+}
+
+// SetNotFound remembers that the guild doesn't exist for NotFoundTTL.
+func (r *GuildRepo) SetNotFound(ctx context.Context, guildID int) (cache.Meta, error) {
+	return setNotFound(ctx, r.cache, r.BuildKey(guildID))
+}
+
+// DeleteNotFound forgets that the guild didn't exist.
+func (r *GuildRepo) DeleteNotFound(ctx context.Context, guildID int) error {
+	return deleteNotFound(ctx, r.cache, r.BuildKey(guildID))
+}
+
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *GuildRepo) Lock(ctx context.Context, guildID int) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(guildID), ScrapeLockTTL)
+}
+
+func (r *GuildRepo) BuildKey(guildID int) string {
 	return fmt.Sprintf("guild:%d", guildID)
 }
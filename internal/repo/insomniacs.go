@@ -8,42 +8,48 @@ import (
 	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
-const (
-	InsomniacsTTL = 5 * time.Minute
-)
+// InsomniacsTTL keeps lists fresh for five minutes and serves them stale for
+// up to an hour while upstream is refreshed or unavailable.
+var InsomniacsTTL = cache.TTL{Soft: 5 * time.Minute, Hard: time.Hour}
 
 type InsomniacsRepo struct {
-	cache *cache.Client
+	cache cache.Cache
 }
 
-func NewInsomniacsRepo(cacheClient *cache.Client) *InsomniacsRepo {
+func NewInsomniacsRepo(cacheClient cache.Cache) *InsomniacsRepo {
 	return &InsomniacsRepo{
 		cache: cacheClient,
 	}
 }
 
-func (r *InsomniacsRepo) Get(ctx context.Context, includeAll bool) ([]types.Insomniac, error) {
-	key := r.buildKey(includeAll)
+func (r *InsomniacsRepo) Get(ctx context.Context, includeAll bool) (*types.InsomniacList, cache.Meta, error) {
+	key := r.BuildKey(includeAll)
 
-	var insomniacs []types.Insomniac
-	if err := r.cache.Get(ctx, key, &insomniacs); err != nil {
-		return nil, err
+	var insomniacs types.InsomniacList
+	meta, err := r.cache.GetEntry(ctx, key, &insomniacs)
+	if err != nil {
+		return nil, cache.Meta{}, err
 	}
 
-	return insomniacs, nil
+	return &insomniacs, meta, nil
 }
 
-func (r *InsomniacsRepo) Set(ctx context.Context, insomniacs []types.Insomniac, includeAll bool) error {
-	key := r.buildKey(includeAll)
-	return r.cache.SetWithTTL(ctx, key, insomniacs, InsomniacsTTL)
+func (r *InsomniacsRepo) Set(ctx context.Context, insomniacs *types.InsomniacList, includeAll bool, sources []string) (cache.Meta, error) {
+	key := r.BuildKey(includeAll)
+	return r.cache.SetEntry(ctx, key, insomniacs, InsomniacsTTL, sources...)
 }
 
 func (r *InsomniacsRepo) Delete(ctx context.Context, includeAll bool) error {
-	key := r.buildKey(includeAll)
+	key := r.BuildKey(includeAll)
 	return r.cache.Delete(ctx, key)
 }
 
-func (r *InsomniacsRepo) buildKey(includeAll bool) string {
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *InsomniacsRepo) Lock(ctx context.Context, includeAll bool) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(includeAll), ScrapeLockTTL)
+}
+
+func (r *InsomniacsRepo) BuildKey(includeAll bool) string {
 	if includeAll {
 		return "insomniacs:all"
 	}
@@ -0,0 +1,7 @@
+package repo
+
+import "time"
+
+// ScrapeLockTTL bounds how long one instance may hold the lock for scraping
+// a resource before another instance gives up waiting and scrapes it too.
+const ScrapeLockTTL = 2 * time.Minute
@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethaan/miracle74-api/pkg/cache"
+)
+
+// NotFoundTTL is how long a lookup of a character or guild that doesn't
+// exist is answered from the cache instead of asking upstream again. It is
+// short because names get created, and it never goes stale: the entry is
+// either there or not.
+var NotFoundTTL = cache.TTL{Soft: 5 * time.Minute, Hard: 5 * time.Minute}
+
+// notFoundKey is where the not-found marker for the entry at key lives.
+func notFoundKey(key string) string {
+	return "notfound:" + key
+}
+
+// getNotFound returns cache.ErrCacheMiss unless key is marked as not found.
+func getNotFound(ctx context.Context, c cache.Cache, key string) (cache.Meta, error) {
+	var marker bool
+	return c.GetEntry(ctx, notFoundKey(key), &marker)
+}
+
+func setNotFound(ctx context.Context, c cache.Cache, key string) (cache.Meta, error) {
+	return c.SetEntry(ctx, notFoundKey(key), true, NotFoundTTL)
+}
+
+func deleteNotFound(ctx context.Context, c cache.Cache, keys ...string) error {
+	markers := make([]string, len(keys))
+	for i, key := range keys {
+		markers[i] = notFoundKey(key)
+	}
+	return c.Delete(ctx, markers...)
+}
@@ -8,34 +8,35 @@ import (
 	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
-const (
-	PowerGamersTTL = 5 * time.Minute
-)
+// PowerGamersTTL keeps lists fresh for five minutes and serves them stale
+// for up to an hour while upstream is refreshed or unavailable.
+var PowerGamersTTL = cache.TTL{Soft: 5 * time.Minute, Hard: time.Hour}
 
 type PowerGamersRepo struct {
-	cache *cache.Client
+	cache cache.Cache
 }
 
-func NewPowerGamersRepo(cacheClient *cache.Client) *PowerGamersRepo {
+func NewPowerGamersRepo(cacheClient cache.Cache) *PowerGamersRepo {
 	return &PowerGamersRepo{
 		cache: cacheClient,
 	}
 }
 
-func (r *PowerGamersRepo) Get(ctx context.Context, includeAll bool, list string, vocation string) ([]types.PowerGamer, error) {
+func (r *PowerGamersRepo) Get(ctx context.Context, includeAll bool, list string, vocation string) (*types.PowerGamerList, cache.Meta, error) {
 	key := r.BuildKey(includeAll, list, vocation)
 
-	var powerGamers []types.PowerGamer
-	if err := r.cache.Get(ctx, key, &powerGamers); err != nil {
-		return nil, err
+	var powerGamers types.PowerGamerList
+	meta, err := r.cache.GetEntry(ctx, key, &powerGamers)
+	if err != nil {
+		return nil, cache.Meta{}, err
 	}
 
-	return powerGamers, nil
+	return &powerGamers, meta, nil
 }
 
-func (r *PowerGamersRepo) Set(ctx context.Context, powerGamers []types.PowerGamer, includeAll bool, list string, vocation string) error {
+func (r *PowerGamersRepo) Set(ctx context.Context, powerGamers *types.PowerGamerList, includeAll bool, list string, vocation string, sources []string) (cache.Meta, error) {
 	key := r.BuildKey(includeAll, list, vocation)
-	return r.cache.SetWithTTL(ctx, key, powerGamers, PowerGamersTTL)
+	return r.cache.SetEntry(ctx, key, powerGamers, PowerGamersTTL, sources...)
 }
 
 func (r *PowerGamersRepo) Delete(ctx context.Context, includeAll bool, list string, vocation string) error {
@@ -43,6 +44,11 @@ func (r *PowerGamersRepo) Delete(ctx context.Context, includeAll bool, list stri
 	return r.cache.Delete(ctx, key)
 }
 
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *PowerGamersRepo) Lock(ctx context.Context, includeAll bool, list string, vocation string) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(includeAll, list, vocation), ScrapeLockTTL)
+}
+
 func (r *PowerGamersRepo) BuildKey(includeAll bool, list string, vocation string) string {
 	scope := "page:1"
 	if includeAll {
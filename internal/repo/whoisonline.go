@@ -8,34 +8,35 @@ import (
 	"github.com/ethaan/miracle74-api/pkg/cache"
 )
 
-const (
-	WhoIsOnlineTTL = 15 * time.Second
-)
+// WhoIsOnlineTTL keeps the list fresh for 15 seconds and serves it stale for
+// up to five minutes while upstream is refreshed or unavailable.
+var WhoIsOnlineTTL = cache.TTL{Soft: 15 * time.Second, Hard: 5 * time.Minute}
 
 type WhoIsOnlineRepo struct {
-	cache *cache.Client
+	cache cache.Cache
 }
 
-func NewWhoIsOnlineRepo(cacheClient *cache.Client) *WhoIsOnlineRepo {
+func NewWhoIsOnlineRepo(cacheClient cache.Cache) *WhoIsOnlineRepo {
 	return &WhoIsOnlineRepo{
 		cache: cacheClient,
 	}
 }
 
-func (r *WhoIsOnlineRepo) Get(ctx context.Context, order string) ([]types.OnlinePlayer, error) {
+func (r *WhoIsOnlineRepo) Get(ctx context.Context, order string) ([]types.OnlinePlayer, cache.Meta, error) {
 	key := r.BuildKey(order)
 
 	var onlinePlayers []types.OnlinePlayer
-	if err := r.cache.Get(ctx, key, &onlinePlayers); err != nil {
-		return nil, err
+	meta, err := r.cache.GetEntry(ctx, key, &onlinePlayers)
+	if err != nil {
+		return nil, cache.Meta{}, err
 	}
 
-	return onlinePlayers, nil
+	return onlinePlayers, meta, nil
 }
 
-func (r *WhoIsOnlineRepo) Set(ctx context.Context, onlinePlayers []types.OnlinePlayer, order string) error {
+func (r *WhoIsOnlineRepo) Set(ctx context.Context, onlinePlayers []types.OnlinePlayer, order string, sources []string) (cache.Meta, error) {
 	key := r.BuildKey(order)
-	return r.cache.SetWithTTL(ctx, key, onlinePlayers, WhoIsOnlineTTL)
+	return r.cache.SetEntry(ctx, key, onlinePlayers, WhoIsOnlineTTL, sources...)
 }
 
 func (r *WhoIsOnlineRepo) Delete(ctx context.Context, order string) error {
@@ -43,6 +44,11 @@ func (r *WhoIsOnlineRepo) Delete(ctx context.Context, order string) error {
 	return r.cache.Delete(ctx, key)
 }
 
+// Lock takes the cache-wide scrape lock for the entry, see ScrapeLockTTL.
+func (r *WhoIsOnlineRepo) Lock(ctx context.Context, order string) (unlock func(), err error) {
+	return r.cache.Lock(ctx, r.BuildKey(order), ScrapeLockTTL)
+}
+
 func (r *WhoIsOnlineRepo) BuildKey(order string) string {
 	orderKey := "name"
 	if order != "" {
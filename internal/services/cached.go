@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethaan/miracle74-api/internal/freshness"
+	"github.com/ethaan/miracle74-api/internal/repo"
+	"github.com/ethaan/miracle74-api/pkg/cache"
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+// refreshTimeout bounds a scrape, which is shared by every request waiting
+// for it and may outlive the one that started it. A scrape nobody waits for
+// any more is cancelled sooner, see flights.
+const refreshTimeout = repo.ScrapeLockTTL
+
+// lockPollInterval is how often an instance waiting on another one's scrape
+// checks whether it has finished.
+const lockPollInterval = 250 * time.Millisecond
+
+// inflight coalesces concurrent scrapes of the same cache key within this
+// process.
+var inflight flights
+
+// cachedResource describes how one resource is read from the cache, scraped
+// and stored.
+type cachedResource[T any] struct {
+	key    string
+	get    func(ctx context.Context) (T, cache.Meta, error)
+	set    func(ctx context.Context, value T, sources []string) (cache.Meta, error)
+	lock   func(ctx context.Context) (unlock func(), err error)
+	scrape func(ctx context.Context) (T, error)
+	// cacheable reports whether a scraped value may be stored. Nil means
+	// always.
+	cacheable func(value T) bool
+
+	// notFound is the error scrape returns when the resource doesn't exist.
+	// If set, that outcome is cached too, through getNotFound and
+	// setNotFound, so repeated lookups of a bad name don't reach upstream.
+	// An entry cached while the resource still existed is dropped through
+	// delete, so a deleted or renamed one isn't served until it expires.
+	notFound    error
+	getNotFound func(ctx context.Context) (cache.Meta, error)
+	setNotFound func(ctx context.Context) (cache.Meta, error)
+	delete      func(ctx context.Context) error
+}
+
+type scraped[T any] struct {
+	value T
+	meta  cache.Meta
+}
+
+// load serves the resource from the cache while it is fresh. Past its soft
+// TTL the cached value is still returned right away and refreshed in the
+// background, which also keeps serving it while upstream is failing. Only a
+// missing entry makes the caller wait for a scrape.
+func (r cachedResource[T]) load(ctx context.Context) (T, error) {
+	value, meta, err := r.get(ctx)
+	switch {
+	case err == nil && !meta.Stale(time.Now()):
+		slog.DebugContext(ctx, "cache hit", "key", r.key)
+		freshness.Record(ctx, freshness.Hit, meta)
+		return value, nil
+	case err == nil:
+		// Another instance may have found the resource gone meanwhile.
+		if r.answerNotFound(ctx) {
+			var zero T
+			return zero, r.notFound
+		}
+		slog.DebugContext(ctx, "serving stale entry", "key", r.key, "stored_at", meta.StoredAt)
+		freshness.Record(ctx, freshness.Stale, meta)
+		r.refreshInBackground(ctx)
+		return value, nil
+	case !errors.Is(err, cache.ErrCacheMiss):
+		slog.WarnContext(ctx, "cache error", "key", r.key, "error", err)
+	default:
+		slog.DebugContext(ctx, "cache miss", "key", r.key)
+	}
+
+	if r.answerNotFound(ctx) {
+		var zero T
+		return zero, r.notFound
+	}
+
+	result, err := r.refresh(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	freshness.Record(ctx, freshness.Miss, result.meta)
+	return result.value, nil
+}
+
+func (r cachedResource[T]) refreshInBackground(ctx context.Context) {
+	go func() {
+		if _, err := r.refresh(context.WithoutCancel(ctx)); err != nil {
+			slog.WarnContext(ctx, "background refresh failed, keeping stale entry", "key", r.key, "error", err)
+		}
+	}()
+}
+
+// refresh scrapes and stores the resource. Concurrent callers for the same
+// key share a single scrape, which keeps running while any of them still
+// waits for it.
+func (r cachedResource[T]) refresh(ctx context.Context) (scraped[T], error) {
+	val, shared, err := inflight.do(ctx, r.key, refreshTimeout, func(ctx context.Context) (interface{}, error) {
+		return r.scrapeLocked(ctx)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return scraped[T]{}, fmt.Errorf("gave up waiting for %s: %w", r.key, ctx.Err())
+		}
+		return scraped[T]{}, err
+	}
+	if shared {
+		slog.DebugContext(ctx, "shared in-flight scrape", "key", r.key)
+	}
+	return val.(scraped[T]), nil
+}
+
+// scrapeLocked scrapes the resource while holding its cache lock, so API
+// instances sharing a cache don't all scrape the same page. An instance that
+// finds the lock taken waits for the holder's result instead, and only
+// scrapes itself if the holder finishes without storing a fresh entry.
+func (r cachedResource[T]) scrapeLocked(ctx context.Context) (scraped[T], error) {
+	unlock, err := r.lock(ctx)
+	for errors.Is(err, cache.ErrLocked) {
+		slog.DebugContext(ctx, "waiting for another instance to scrape", "key", r.key)
+		if err := sleep(ctx, lockPollInterval); err != nil {
+			return scraped[T]{}, fmt.Errorf("gave up waiting for %s: %w", r.key, err)
+		}
+
+		if value, meta, err := r.get(ctx); err == nil && !meta.Stale(time.Now()) {
+			return scraped[T]{value: value, meta: meta}, nil
+		}
+		if _, ok := r.cachedNotFound(ctx); ok {
+			return scraped[T]{}, r.notFound
+		}
+		unlock, err = r.lock(ctx)
+	}
+
+	if err != nil {
+		slog.WarnContext(ctx, "scrape lock unavailable, scraping anyway", "key", r.key, "error", err)
+	} else {
+		defer unlock()
+	}
+
+	// A refresh queued behind one that just finished finds its result here.
+	if value, meta, err := r.get(ctx); err == nil && !meta.Stale(time.Now()) {
+		slog.DebugContext(ctx, "refreshed meanwhile, skipping scrape", "key", r.key)
+		return scraped[T]{value: value, meta: meta}, nil
+	}
+
+	// Note the pages the scrape fetches so they can be shown with the data.
+	ctx = miracle74.TrackSources(ctx)
+
+	value, err := r.scrape(ctx)
+	if err != nil {
+		if r.notFound != nil && errors.Is(err, r.notFound) {
+			r.storeNotFound(ctx)
+		}
+		return scraped[T]{}, err
+	}
+	return scraped[T]{value: value, meta: r.store(ctx, value, miracle74.Sources(ctx))}, nil
+}
+
+// store caches value, scraped from sources, unless it is not cacheable and
+// returns the metadata it was stored with.
+func (r cachedResource[T]) store(ctx context.Context, value T, sources []string) cache.Meta {
+	uncached := cache.Meta{StoredAt: time.Now().UTC(), Sources: sources, Version: miracle74.ParserVersion}
+
+	if r.cacheable != nil && !r.cacheable(value) {
+		slog.WarnContext(ctx, "not caching incomplete result", "key", r.key)
+		return uncached
+	}
+
+	meta, err := r.set(ctx, value, sources)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to cache", "key", r.key, "error", err)
+		return uncached
+	}
+
+	slog.DebugContext(ctx, "cached", "key", r.key)
+	return meta
+}
+
+// answerNotFound reports whether the resource is cached as not existing, and
+// records the request as answered from that entry if so.
+func (r cachedResource[T]) answerNotFound(ctx context.Context) bool {
+	meta, ok := r.cachedNotFound(ctx)
+	if ok {
+		slog.DebugContext(ctx, "cached not found", "key", r.key)
+		observeNotFound(ctx, r.key, "hit")
+		freshness.Record(ctx, freshness.Hit, meta)
+	}
+	return ok
+}
+
+// cachedNotFound reports whether the resource is cached as not existing.
+func (r cachedResource[T]) cachedNotFound(ctx context.Context) (cache.Meta, bool) {
+	if r.getNotFound == nil {
+		return cache.Meta{}, false
+	}
+
+	meta, err := r.getNotFound(ctx)
+	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		slog.WarnContext(ctx, "cache error", "key", r.key, "error", err)
+	}
+	return meta, err == nil
+}
+
+func (r cachedResource[T]) storeNotFound(ctx context.Context) {
+	if r.delete != nil {
+		if err := r.delete(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to drop entry of missing resource", "key", r.key, "error", err)
+		}
+	}
+
+	if _, err := r.setNotFound(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to cache not found", "key", r.key, "error", err)
+		return
+	}
+
+	slog.DebugContext(ctx, "cached not found", "key", r.key)
+	observeNotFound(ctx, r.key, "stored")
+}
+
+// sleep pauses for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethaan/miracle74-api/internal/freshness"
+	"github.com/ethaan/miracle74-api/pkg/cache"
+)
+
+// newResource returns a string resource cached in memory under key.
+func newResource(c cache.Cache, key string, ttl cache.TTL, scrape func(ctx context.Context) (string, error)) cachedResource[string] {
+	return cachedResource[string]{
+		key: key,
+		get: func(ctx context.Context) (string, cache.Meta, error) {
+			var value string
+			meta, err := c.GetEntry(ctx, key, &value)
+			return value, meta, err
+		},
+		set: func(ctx context.Context, value string, sources []string) (cache.Meta, error) {
+			return c.SetEntry(ctx, key, value, ttl)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return c.Lock(ctx, key, time.Minute)
+		},
+		scrape: scrape,
+	}
+}
+
+func newMemoryCache() cache.Cache {
+	return cache.New(cache.NewMemoryBackend(100, 0), time.Minute)
+}
+
+func TestCachedResourceCoalescesMisses(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	r := newResource(newMemoryCache(), "guild:386", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "Redemption", nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := r.load(context.Background()); err != nil || value != "Redemption" {
+				t.Errorf("load() = %q, %v", value, err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("scraped %d times, want 1", n)
+	}
+}
+
+func TestCachedResourceCancelsAbandonedScrape(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	r := newResource(newMemoryCache(), "character:Oten", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go r.load(ctx)
+	<-started
+	cancel()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("scrape kept running after its only caller left")
+	}
+}
+
+func TestCachedResourceKeepsScrapeForRemainingWaiter(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r := newResource(newMemoryCache(), "character:Oten", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "Oten", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go r.load(ctx)
+	<-started
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := r.load(context.Background())
+		result <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if err := <-result; err != nil {
+		t.Errorf("load() error = %v, want the shared scrape's result", err)
+	}
+}
+
+func TestCachedResourceWaitsForOtherInstance(t *testing.T) {
+	c := newMemoryCache()
+	ttl := cache.TTL{Soft: time.Minute, Hard: time.Hour}
+
+	// Another instance holds the lock and stores its result a bit later.
+	unlock, err := c.Lock(context.Background(), "powergamers:today", time.Minute)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		c.SetEntry(context.Background(), "powergamers:today", "theirs", ttl)
+		unlock()
+	}()
+
+	var calls atomic.Int32
+	r := newResource(c, "powergamers:today", ttl, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "ours", nil
+	})
+
+	if value, err := r.load(context.Background()); err != nil || value != "theirs" {
+		t.Errorf("load() = %q, %v, want the other instance's result", value, err)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("scraped %d times, want 0", n)
+	}
+}
+
+func TestCachedResourceScrapesWhenOtherInstanceGivesUp(t *testing.T) {
+	c := newMemoryCache()
+
+	unlock, err := c.Lock(context.Background(), "guild:12", time.Minute)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		unlock() // Released without storing anything, e.g. a failed scrape.
+	}()
+
+	var calls atomic.Int32
+	r := newResource(c, "guild:12", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "Red Rose", nil
+	})
+
+	if value, err := r.load(context.Background()); err != nil || value != "Red Rose" {
+		t.Errorf("load() = %q, %v, want own scrape", value, err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("scraped %d times, want 1", n)
+	}
+}
+
+func TestCachedResourceServesStale(t *testing.T) {
+	c := newMemoryCache()
+	if _, err := c.SetEntry(context.Background(), "character:Oten", "old", cache.TTL{Hard: time.Hour}); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	r := newResource(c, "character:Oten", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		return "new", nil
+	})
+
+	ctx := freshnessContext()
+	value, err := r.load(ctx)
+	if err != nil || value != "old" {
+		t.Fatalf("load() = %q, %v, want stale value", value, err)
+	}
+	if report, _ := freshness.FromContext(ctx); report.Status != freshness.Stale {
+		t.Errorf("status = %q, want STALE", report.Status)
+	}
+
+	// The refresh runs in the background; wait for it to store its result.
+	deadline := time.Now().Add(time.Second)
+	for {
+		var stored string
+		meta, err := c.GetEntry(context.Background(), "character:Oten", &stored)
+		if err == nil && !meta.Stale(time.Now()) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stale entry was not refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx = freshnessContext()
+	if value, err := r.load(ctx); err != nil || value != "new" {
+		t.Errorf("load() after refresh = %q, %v, want refreshed value", value, err)
+	}
+	if report, _ := freshness.FromContext(ctx); report.Status != freshness.Hit {
+		t.Errorf("status after refresh = %q, want HIT", report.Status)
+	}
+}
+
+func TestCachedResourceRefreshesStaleOnce(t *testing.T) {
+	c := newMemoryCache()
+	if _, err := c.SetEntry(context.Background(), "insomniacs:all", "old", cache.TTL{Hard: time.Hour}); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	r := newResource(c, "insomniacs:all", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "new", nil
+	})
+
+	// Every load returns the stale value while the refresh is still blocked.
+	for range 10 {
+		if value, err := r.load(context.Background()); err != nil || value != "old" {
+			t.Fatalf("load() = %q, %v, want stale value", value, err)
+		}
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		var stored string
+		if _, err := c.GetEntry(context.Background(), "insomniacs:all", &stored); err == nil && stored == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stale entry was not refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("scraped %d times, want 1", n)
+	}
+}
+
+func TestCachedResourceKeepsStaleWhenUpstreamFails(t *testing.T) {
+	c := newMemoryCache()
+	c.SetEntry(context.Background(), "whoisonline:name", "players", cache.TTL{Hard: time.Hour})
+
+	failed := make(chan struct{}, 2)
+	r := newResource(c, "whoisonline:name", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		failed <- struct{}{}
+		return "", errors.New("upstream unavailable")
+	})
+
+	if value, err := r.load(context.Background()); err != nil || value != "players" {
+		t.Fatalf("load() = %q, %v, want stale value", value, err)
+	}
+	<-failed
+
+	if value, err := r.load(context.Background()); err != nil || value != "players" {
+		t.Errorf("load() after failed refresh = %q, %v, want stale value", value, err)
+	}
+}
+
+func TestCachedResourceCachesNotFound(t *testing.T) {
+	c := newMemoryCache()
+	errNotFound := errors.New("does not exist")
+	notFoundKey := "notfound:character:Nobody"
+
+	var calls atomic.Int32
+	r := newResource(c, "character:Nobody", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "", fmt.Errorf("failed to scrape character: %w", errNotFound)
+	})
+	r = withNotFound(c, r, errNotFound)
+
+	for range 3 {
+		if _, err := r.load(context.Background()); !errors.Is(err, errNotFound) {
+			t.Fatalf("load() error = %v, want not found", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("scraped %d times, want 1", n)
+	}
+
+	c.Delete(context.Background(), notFoundKey)
+	r.load(context.Background())
+	if n := calls.Load(); n != 2 {
+		t.Errorf("scraped %d times after eviction, want 2", n)
+	}
+}
+
+func TestCachedResourceDropsEntryOnNotFound(t *testing.T) {
+	c := newMemoryCache()
+	errNotFound := errors.New("does not exist")
+	if _, err := c.SetEntry(context.Background(), "character:Renamed", "old", cache.TTL{Hard: time.Hour}); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	var calls atomic.Int32
+	r := withNotFound(c, newResource(c, "character:Renamed", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "", fmt.Errorf("failed to scrape character: %w", errNotFound)
+	}), errNotFound)
+
+	// The stale entry is served once while the refresh finds it gone.
+	if value, err := r.load(context.Background()); err != nil || value != "old" {
+		t.Fatalf("load() = %q, %v, want stale value", value, err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := r.getNotFound(context.Background()); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("not found was not cached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var stored string
+	if _, err := c.GetEntry(context.Background(), "character:Renamed", &stored); !errors.Is(err, cache.ErrCacheMiss) {
+		t.Errorf("GetEntry() error = %v, want the stale entry dropped", err)
+	}
+	for range 3 {
+		if _, err := r.load(context.Background()); !errors.Is(err, errNotFound) {
+			t.Fatalf("load() error = %v, want not found", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("scraped %d times, want 1", n)
+	}
+}
+
+func TestCachedResourceChecksNotFoundBeforeRefresh(t *testing.T) {
+	c := newMemoryCache()
+	errNotFound := errors.New("does not exist")
+	c.SetEntry(context.Background(), "guild:7", "old", cache.TTL{Hard: time.Hour})
+
+	var calls atomic.Int32
+	r := withNotFound(c, newResource(c, "guild:7", cache.TTL{Soft: time.Minute, Hard: time.Hour}, func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "new", nil
+	}), errNotFound)
+	// Another instance found the guild gone but couldn't drop the entry.
+	r.setNotFound(context.Background())
+
+	if _, err := r.load(context.Background()); !errors.Is(err, errNotFound) {
+		t.Errorf("load() error = %v, want not found", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := calls.Load(); n != 0 {
+		t.Errorf("scraped %d times, want no background refresh", n)
+	}
+}
+
+// withNotFound makes r cache errNotFound results next to its entry in c.
+func withNotFound(c cache.Cache, r cachedResource[string], errNotFound error) cachedResource[string] {
+	notFoundKey := "notfound:" + r.key
+	r.notFound = errNotFound
+	r.getNotFound = func(ctx context.Context) (cache.Meta, error) {
+		var marker bool
+		return c.GetEntry(ctx, notFoundKey, &marker)
+	}
+	r.setNotFound = func(ctx context.Context) (cache.Meta, error) {
+		return c.SetEntry(ctx, notFoundKey, true, cache.TTL{Soft: time.Minute, Hard: time.Minute})
+	}
+	r.delete = func(ctx context.Context) error {
+		return c.Delete(ctx, r.key)
+	}
+	return r
+}
+
+// freshnessContext returns a context that records a freshness.Report, as
+// requests passing through freshness.Middleware do.
+func freshnessContext() context.Context {
+	var ctx context.Context
+	freshness.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	return ctx
+}
@@ -2,10 +2,10 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 
+	"github.com/ethaan/miracle74-api/internal/names"
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/types"
 	"github.com/ethaan/miracle74-api/pkg/cache"
@@ -17,36 +17,82 @@ type CharacterService struct {
 	repo   *repo.CharacterRepo
 }
 
-func NewCharacterService(characterRepo *repo.CharacterRepo) *CharacterService {
+func NewCharacterService(client *miracle74.Client, characterRepo *repo.CharacterRepo) *CharacterService {
 	return &CharacterService{
-		client: miracle74.NewClient(),
+		client: client,
 		repo:   characterRepo,
 	}
 }
 
+// GetCharacter looks up the character called name, in any case and with
+// stray whitespace. Names no character can have fail with names.ErrInvalid
+// before anything is fetched.
 func (s *CharacterService) GetCharacter(ctx context.Context, name string) (*types.Character, error) {
-	character, err := s.repo.Get(ctx, name)
-	if err == nil {
-		log.Printf("Cache hit for character:%s", name)
-		return character, nil
+	name, err := names.Normalize(name)
+	if err != nil {
+		return nil, err
 	}
 
-	if !errors.Is(err, cache.ErrCacheMiss) {
-		log.Printf("Cache error: %v", err)
-	} else {
-		log.Printf("Cache miss for character:%s", name)
-	}
+	return cachedResource[*types.Character]{
+		key: s.repo.BuildKey(name),
+		get: func(ctx context.Context) (*types.Character, cache.Meta, error) {
+			return s.repo.Get(ctx, name)
+		},
+		set: func(ctx context.Context, character *types.Character, sources []string) (cache.Meta, error) {
+			canonical, err := names.Normalize(character.Name)
+			if err != nil || names.Key(canonical) == names.Key(name) {
+				return s.repo.Set(ctx, name, character, sources)
+			}
 
-	character, err = s.client.ScrapeCharacter(name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scrape character: %w", err)
-	}
+			// Upstream matched a name we fold differently: keep the entry
+			// under the name the site spells it with and point this one at it.
+			meta, err := s.repo.Set(ctx, canonical, character, sources)
+			if err != nil {
+				return meta, err
+			}
+			if err := s.repo.SetAlias(ctx, name, canonical); err != nil {
+				slog.WarnContext(ctx, "failed to cache name alias", "name", name, "canonical", canonical, "error", err)
+			}
+			return meta, nil
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.repo.Lock(ctx, name)
+		},
+		notFound: miracle74.ErrCharacterNotFound,
+		getNotFound: func(ctx context.Context) (cache.Meta, error) {
+			return s.repo.GetNotFound(ctx, name)
+		},
+		setNotFound: func(ctx context.Context) (cache.Meta, error) {
+			return s.repo.SetNotFound(ctx, name)
+		},
+		delete: func(ctx context.Context) error {
+			return s.repo.Delete(ctx, name)
+		},
+		scrape: func(ctx context.Context) (*types.Character, error) {
+			character, err := s.client.ScrapeCharacter(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape character: %w", err)
+			}
 
-	if err := s.repo.Set(ctx, name, character); err != nil {
-		log.Printf("Failed to cache character: %v", err)
-	} else {
-		log.Printf("Cached character:%s", name)
-	}
+			var others []string
+			for _, other := range character.AccountCharacters {
+				if other.Status != "deleted" {
+					others = append(others, other.Name)
+				}
+			}
+			forgetNotFound(ctx, s.repo, others)
+			return character, nil
+		},
+	}.load(ctx)
+}
 
-	return character, nil
+// forgetNotFound drops the cached not-found results of characters that were
+// just seen upstream, so looking them up works right away.
+//
+// A failure only delays those lookups by NotFoundTTL, so it is logged rather
+// than failing the scrape.
+func forgetNotFound(ctx context.Context, characterRepo *repo.CharacterRepo, names []string) {
+	if err := characterRepo.DeleteNotFound(ctx, names...); err != nil {
+		slog.WarnContext(ctx, "failed to forget not found characters", "names", len(names), "error", err)
+	}
 }
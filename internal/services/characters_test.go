@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethaan/miracle74-api/internal/repo"
+	"github.com/ethaan/miracle74-api/internal/types"
+	"github.com/ethaan/miracle74-api/pkg/cache"
+	"github.com/ethaan/miracle74-api/pkg/miracle74"
+)
+
+// countingBackend counts Delete calls, each of which is a round trip to a
+// shared backend.
+type countingBackend struct {
+	cache.Backend
+	deletes int
+}
+
+func (b *countingBackend) Delete(ctx context.Context, keys ...string) error {
+	b.deletes++
+	return b.Backend.Delete(ctx, keys...)
+}
+
+func TestForgetNotFoundDeletesOnce(t *testing.T) {
+	backend := &countingBackend{Backend: cache.NewMemoryBackend(100, 0)}
+	characters := repo.NewCharacterRepo(cache.New(backend, time.Minute))
+	names := []string{"Alice", "Bob", "Carol"}
+	for _, name := range names {
+		if _, err := characters.SetNotFound(context.Background(), name); err != nil {
+			t.Fatalf("SetNotFound(%q) error = %v", name, err)
+		}
+	}
+
+	forgetNotFound(context.Background(), characters, names)
+
+	if backend.deletes != 1 {
+		t.Errorf("deleted in %d round trips, want 1", backend.deletes)
+	}
+	for _, name := range names {
+		if _, err := characters.GetNotFound(context.Background(), name); !errors.Is(err, cache.ErrCacheMiss) {
+			t.Errorf("GetNotFound(%q) error = %v, want cache miss", name, err)
+		}
+	}
+}
+
+func TestGetCharacterAliasesNameSpelledDifferently(t *testing.T) {
+	// Upstream answers every name with Oten's page, as it does for names it
+	// matches loosely.
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		http.ServeFile(w, r, "../../pkg/miracle74/testdata/characters_name-Oten.html")
+	}))
+	defer srv.Close()
+
+	c := newMemoryCache()
+	characters := repo.NewCharacterRepo(c)
+	service := NewCharacterService(miracle74.NewClient(miracle74.WithBaseURL(srv.URL)), characters)
+
+	if character, err := service.GetCharacter(context.Background(), "Oten-x"); err != nil || character.Name != "Oten" {
+		t.Fatalf("GetCharacter(Oten-x) = %v, %v, want Oten", character, err)
+	}
+	if _, err := service.GetCharacter(context.Background(), "oten"); err != nil {
+		t.Fatalf("GetCharacter(oten) error = %v", err)
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times, want 1", n)
+	}
+
+	var stored types.Character
+	if _, err := c.GetEntry(context.Background(), characters.BuildKey("Oten-x"), &stored); !errors.Is(err, cache.ErrCacheMiss) {
+		t.Errorf("GetEntry(Oten-x) error = %v, want only the alias stored under it", err)
+	}
+	if character, _, err := characters.Get(context.Background(), "Oten-x"); err != nil || character.Name != "Oten" {
+		t.Errorf("Get(Oten-x) = %v, %v, want Oten through the alias", character, err)
+	}
+}
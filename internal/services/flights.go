@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flights coalesces concurrent calls for the same key within this process,
+// like singleflight, but cancels a call once every caller waiting for it has
+// gone away, so abandoned scrapes don't keep spending the upstream budget.
+type flights struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs fn for key unless a call for it is already in flight, then waits
+// for that call's result or until ctx is done. fn gets a context carrying
+// the values of the caller that started it, which is cancelled when the
+// last waiter leaves or after timeout. shared reports whether the call was
+// started by another caller.
+func (g *flights) do(ctx context.Context, key string, timeout time.Duration, fn func(ctx context.Context) (interface{}, error)) (val interface{}, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go g.run(callCtx, key, f, fn)
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.val, ok, f.err
+	case <-ctx.Done():
+		g.leave(key, f)
+		return nil, ok, ctx.Err()
+	}
+}
+
+func (g *flights) run(ctx context.Context, key string, f *flight, fn func(ctx context.Context) (interface{}, error)) {
+	defer f.cancel()
+	f.val, f.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(f.done)
+}
+
+// leave drops a waiter that gave up and cancels the call if it was the last.
+// A cancelled call is forgotten at once, so the next caller starts afresh
+// instead of joining it.
+func (g *flights) leave(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+	f.cancel()
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
@@ -2,9 +2,8 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
+	"strings"
 
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/types"
@@ -13,40 +12,105 @@ import (
 )
 
 type GuildService struct {
-	client *miracle74.Client
-	repo   *repo.GuildRepo
+	client     *miracle74.Client
+	repo       *repo.GuildRepo
+	directory  *repo.GuildDirectoryRepo
+	characters *repo.CharacterRepo
 }
 
-func NewGuildService(guildRepo *repo.GuildRepo) *GuildService {
+func NewGuildService(client *miracle74.Client, guildRepo *repo.GuildRepo, guildDirectoryRepo *repo.GuildDirectoryRepo, characterRepo *repo.CharacterRepo) *GuildService {
 	return &GuildService{
-		client: miracle74.NewClient(),
-		repo:   guildRepo,
+		client:     client,
+		repo:       guildRepo,
+		directory:  guildDirectoryRepo,
+		characters: characterRepo,
 	}
 }
 
 func (s *GuildService) GetGuild(ctx context.Context, guildID int) (*types.Guild, error) {
-	guild, err := s.repo.Get(ctx, guildID)
-	if err == nil {
-		log.Printf("Cache hit for guild:%d", guildID)
-		return guild, nil
-	}
+	return cachedResource[*types.Guild]{
+		key: s.repo.BuildKey(guildID),
+		get: func(ctx context.Context) (*types.Guild, cache.Meta, error) {
+			return s.repo.Get(ctx, guildID)
+		},
+		set: func(ctx context.Context, guild *types.Guild, sources []string) (cache.Meta, error) {
+			return s.repo.Set(ctx, guildID, guild, sources)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.repo.Lock(ctx, guildID)
+		},
+		notFound: miracle74.ErrGuildNotFound,
+		getNotFound: func(ctx context.Context) (cache.Meta, error) {
+			return s.repo.GetNotFound(ctx, guildID)
+		},
+		setNotFound: func(ctx context.Context) (cache.Meta, error) {
+			return s.repo.SetNotFound(ctx, guildID)
+		},
+		delete: func(ctx context.Context) error {
+			return s.repo.Delete(ctx, guildID)
+		},
+		scrape: func(ctx context.Context) (*types.Guild, error) {
+			guild, err := s.client.ScrapeGuild(ctx, guildID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape guild: %w", err)
+			}
 
-	if !errors.Is(err, cache.ErrCacheMiss) {
-		log.Printf("Cache error: %v", err)
-	} else {
-		log.Printf("Cache miss for guild:%d", guildID)
-	}
+			names := make([]string, 0, len(guild.Members)+len(guild.Invites))
+			for _, member := range guild.Members {
+				names = append(names, member.Name)
+			}
+			for _, invite := range guild.Invites {
+				names = append(names, invite.Name)
+			}
+			forgetNotFound(ctx, s.characters, names)
+			return guild, nil
+		},
+	}.load(ctx)
+}
+
+// ListGuilds returns every guild on the site's guild list.
+func (s *GuildService) ListGuilds(ctx context.Context) ([]types.GuildSummary, error) {
+	return cachedResource[[]types.GuildSummary]{
+		key: s.directory.BuildKey(),
+		get: func(ctx context.Context) ([]types.GuildSummary, cache.Meta, error) {
+			return s.directory.Get(ctx)
+		},
+		set: func(ctx context.Context, guilds []types.GuildSummary, sources []string) (cache.Meta, error) {
+			return s.directory.Set(ctx, guilds, sources)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.directory.Lock(ctx)
+		},
+		scrape: func(ctx context.Context) ([]types.GuildSummary, error) {
+			guilds, err := s.client.ScrapeGuilds(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape guild list: %w", err)
+			}
+			return guilds, nil
+		},
+		// An empty list is more likely a broken page than a server without
+		// guilds, and would make every name lookup fail for a day.
+		cacheable: func(guilds []types.GuildSummary) bool {
+			return len(guilds) > 0
+		},
+	}.load(ctx)
+}
 
-	guild, err = s.client.ScrapeGuild(guildID)
+// GetGuildByName looks up a guild by name, in any case and with stray
+// whitespace, through the guild list. A guild founded since the list was
+// last scraped is not found until it is refreshed.
+func (s *GuildService) GetGuildByName(ctx context.Context, name string) (*types.Guild, error) {
+	guilds, err := s.ListGuilds(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scrape guild: %w", err)
+		return nil, err
 	}
 
-	if err := s.repo.Set(ctx, guildID, guild); err != nil {
-		log.Printf("Failed to cache guild: %v", err)
-	} else {
-		log.Printf("Cached guild:%d", guildID)
+	name = strings.Join(strings.Fields(name), " ")
+	for _, guild := range guilds {
+		if strings.EqualFold(guild.Name, name) {
+			return s.GetGuild(ctx, guild.GuildID)
+		}
 	}
 
-	return guild, nil
+	return nil, fmt.Errorf("no guild called %q: %w", name, miracle74.ErrGuildNotFound)
 }
@@ -2,9 +2,7 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
 
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/types"
@@ -17,44 +15,36 @@ type InsomniacsService struct {
 	repo   *repo.InsomniacsRepo
 }
 
-func NewInsomniacsService(insomniacsRepo *repo.InsomniacsRepo) *InsomniacsService {
+func NewInsomniacsService(client *miracle74.Client, insomniacsRepo *repo.InsomniacsRepo) *InsomniacsService {
 	return &InsomniacsService{
-		client: miracle74.NewClient(),
+		client: client,
 		repo:   insomniacsRepo,
 	}
 }
 
-func (s *InsomniacsService) GetInsomniacs(ctx context.Context, includeAll bool) ([]types.Insomniac, error) {
-	insomniacs, err := s.repo.Get(ctx, includeAll)
-	if err == nil {
-		cacheKey := "insomniacs:page:1"
-		if includeAll {
-			cacheKey = "insomniacs:all"
-		}
-		log.Printf("Cache hit for %s", cacheKey)
-		return insomniacs, nil
-	}
-
-	if !errors.Is(err, cache.ErrCacheMiss) {
-		log.Printf("Cache error: %v", err)
-	} else {
-		cacheKey := "insomniacs:page:1"
-		if includeAll {
-			cacheKey = "insomniacs:all"
-		}
-		log.Printf("Cache miss for %s", cacheKey)
-	}
-
-	insomniacs, err = s.client.ScrapeInsomniacs(includeAll)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scrape insomniacs: %w", err)
-	}
-
-	if err := s.repo.Set(ctx, insomniacs, includeAll); err != nil {
-		log.Printf("Failed to cache insomniacs: %v", err)
-	} else {
-		log.Printf("Cached %d insomniacs", len(insomniacs))
-	}
-
-	return insomniacs, nil
+func (s *InsomniacsService) GetInsomniacs(ctx context.Context, includeAll bool) (*types.InsomniacList, error) {
+	return cachedResource[*types.InsomniacList]{
+		key: s.repo.BuildKey(includeAll),
+		get: func(ctx context.Context) (*types.InsomniacList, cache.Meta, error) {
+			return s.repo.Get(ctx, includeAll)
+		},
+		set: func(ctx context.Context, insomniacs *types.InsomniacList, sources []string) (cache.Meta, error) {
+			return s.repo.Set(ctx, insomniacs, includeAll, sources)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.repo.Lock(ctx, includeAll)
+		},
+		scrape: func(ctx context.Context) (*types.InsomniacList, error) {
+			insomniacs, err := s.client.ScrapeInsomniacs(ctx, includeAll)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape insomniacs: %w", err)
+			}
+			return insomniacs, nil
+		},
+		// A list with holes in it is returned, but not cached, so the next
+		// request gets another chance at the missing pages.
+		cacheable: func(insomniacs *types.InsomniacList) bool {
+			return len(insomniacs.FailedPages) == 0
+		},
+	}.load(ctx)
 }
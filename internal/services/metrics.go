@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	meter = otel.Meter("github.com/ethaan/miracle74-api/internal/services")
+
+	notFoundEvents = must(meter.Int64Counter("cache.not_found",
+		metric.WithDescription("Not-found results stored in the cache (stored) and lookups answered from them (hit), by key family"),
+	))
+)
+
+func must[T any](instrument T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return instrument
+}
+
+// observeNotFound counts a not-found entry for key being stored or served.
+func observeNotFound(ctx context.Context, key, result string) {
+	family, _, _ := strings.Cut(key, ":")
+	notFoundEvents.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("family", family),
+		attribute.String("result", result),
+	))
+}
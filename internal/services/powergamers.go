@@ -2,9 +2,7 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
 
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/types"
@@ -17,38 +15,36 @@ type PowerGamersService struct {
 	repo   *repo.PowerGamersRepo
 }
 
-func NewPowerGamersService(powerGamersRepo *repo.PowerGamersRepo) *PowerGamersService {
+func NewPowerGamersService(client *miracle74.Client, powerGamersRepo *repo.PowerGamersRepo) *PowerGamersService {
 	return &PowerGamersService{
-		client: miracle74.NewClient(),
+		client: client,
 		repo:   powerGamersRepo,
 	}
 }
 
-func (s *PowerGamersService) GetPowerGamers(ctx context.Context, includeAll bool, list string, vocation string) ([]types.PowerGamer, error) {
-	powerGamers, err := s.repo.Get(ctx, includeAll, list, vocation)
-	if err == nil {
-		cacheKey := s.repo.BuildKey(includeAll, list, vocation)
-		log.Printf("Cache hit for %s", cacheKey)
-		return powerGamers, nil
-	}
-
-	if !errors.Is(err, cache.ErrCacheMiss) {
-		log.Printf("Cache error: %v", err)
-	} else {
-		cacheKey := s.repo.BuildKey(includeAll, list, vocation)
-		log.Printf("Cache miss for %s", cacheKey)
-	}
-
-	powerGamers, err = s.client.ScrapePowerGamers(includeAll, list, vocation)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scrape power gamers: %w", err)
-	}
-
-	if err := s.repo.Set(ctx, powerGamers, includeAll, list, vocation); err != nil {
-		log.Printf("Failed to cache power gamers: %v", err)
-	} else {
-		log.Printf("Cached %d power gamers", len(powerGamers))
-	}
-
-	return powerGamers, nil
+func (s *PowerGamersService) GetPowerGamers(ctx context.Context, includeAll bool, list string, vocation string) (*types.PowerGamerList, error) {
+	return cachedResource[*types.PowerGamerList]{
+		key: s.repo.BuildKey(includeAll, list, vocation),
+		get: func(ctx context.Context) (*types.PowerGamerList, cache.Meta, error) {
+			return s.repo.Get(ctx, includeAll, list, vocation)
+		},
+		set: func(ctx context.Context, powerGamers *types.PowerGamerList, sources []string) (cache.Meta, error) {
+			return s.repo.Set(ctx, powerGamers, includeAll, list, vocation, sources)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.repo.Lock(ctx, includeAll, list, vocation)
+		},
+		scrape: func(ctx context.Context) (*types.PowerGamerList, error) {
+			powerGamers, err := s.client.ScrapePowerGamers(ctx, includeAll, list, vocation)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape power gamers: %w", err)
+			}
+			return powerGamers, nil
+		},
+		// A list with holes in it is returned, but not cached, so the next
+		// request gets another chance at the missing pages.
+		cacheable: func(powerGamers *types.PowerGamerList) bool {
+			return len(powerGamers.FailedPages) == 0
+		},
+	}.load(ctx)
 }
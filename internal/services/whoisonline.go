@@ -2,9 +2,7 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
 
 	"github.com/ethaan/miracle74-api/internal/repo"
 	"github.com/ethaan/miracle74-api/internal/types"
@@ -13,42 +11,43 @@ import (
 )
 
 type WhoIsOnlineService struct {
-	client *miracle74.Client
-	repo   *repo.WhoIsOnlineRepo
+	client     *miracle74.Client
+	repo       *repo.WhoIsOnlineRepo
+	characters *repo.CharacterRepo
 }
 
-func NewWhoIsOnlineService(whoIsOnlineRepo *repo.WhoIsOnlineRepo) *WhoIsOnlineService {
+func NewWhoIsOnlineService(client *miracle74.Client, whoIsOnlineRepo *repo.WhoIsOnlineRepo, characterRepo *repo.CharacterRepo) *WhoIsOnlineService {
 	return &WhoIsOnlineService{
-		client: miracle74.NewClient(),
-		repo:   whoIsOnlineRepo,
+		client:     client,
+		repo:       whoIsOnlineRepo,
+		characters: characterRepo,
 	}
 }
 
 func (s *WhoIsOnlineService) GetWhoIsOnline(ctx context.Context, order string) ([]types.OnlinePlayer, error) {
-	onlinePlayers, err := s.repo.Get(ctx, order)
-	if err == nil {
-		cacheKey := s.repo.BuildKey(order)
-		log.Printf("Cache hit for %s", cacheKey)
-		return onlinePlayers, nil
-	}
-
-	if !errors.Is(err, cache.ErrCacheMiss) {
-		log.Printf("Cache error: %v", err)
-	} else {
-		cacheKey := s.repo.BuildKey(order)
-		log.Printf("Cache miss for %s", cacheKey)
-	}
-
-	onlinePlayers, err = s.client.ScrapeWhoIsOnline(order)
-	if err != nil {
-		return nil, fmt.Errorf("failed to scrape who is online: %w", err)
-	}
-
-	if err := s.repo.Set(ctx, onlinePlayers, order); err != nil {
-		log.Printf("Failed to cache who is online: %v", err)
-	} else {
-		log.Printf("Cached %d online players", len(onlinePlayers))
-	}
-
-	return onlinePlayers, nil
+	return cachedResource[[]types.OnlinePlayer]{
+		key: s.repo.BuildKey(order),
+		get: func(ctx context.Context) ([]types.OnlinePlayer, cache.Meta, error) {
+			return s.repo.Get(ctx, order)
+		},
+		set: func(ctx context.Context, onlinePlayers []types.OnlinePlayer, sources []string) (cache.Meta, error) {
+			return s.repo.Set(ctx, onlinePlayers, order, sources)
+		},
+		lock: func(ctx context.Context) (func(), error) {
+			return s.repo.Lock(ctx, order)
+		},
+		scrape: func(ctx context.Context) ([]types.OnlinePlayer, error) {
+			onlinePlayers, err := s.client.ScrapeWhoIsOnline(ctx, order)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scrape who is online: %w", err)
+			}
+
+			names := make([]string, len(onlinePlayers))
+			for i, player := range onlinePlayers {
+				names[i] = player.Name
+			}
+			forgetNotFound(ctx, s.characters, names)
+			return onlinePlayers, nil
+		},
+	}.load(ctx)
 }
@@ -3,23 +3,57 @@ package types
 import "time"
 
 type Character struct {
+	Name        string     `json:"name"`
+	FormerNames []string   `json:"former_names,omitempty"`
+	Sex         string     `json:"sex"`
+	Vocation    string     `json:"vocation,omitempty"`
+	Level       int        `json:"level,omitempty"`
+	Residence   string     `json:"residence,omitempty"`
+	Guild       string     `json:"guild,omitempty"`
+	GuildRank   string     `json:"guild_rank,omitempty"`
+	GuildURL    string     `json:"guild_url,omitempty"`
+	LastLogin   *time.Time `json:"last_login,omitempty"`
+	IsPremium   bool       `json:"is_premium"`
+	Country     string     `json:"country,omitempty"`
+	MarriedTo   string     `json:"married_to,omitempty"`
+	House       *House     `json:"house,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	Deaths      []Death    `json:"deaths,omitempty"`
+	// AccountCharacters are the characters on the same account, including
+	// this one, unless the account hides them.
+	AccountCharacters []AccountCharacter `json:"account_characters,omitempty"`
+}
+
+// House is the house a character rents.
+type House struct {
 	Name      string     `json:"name"`
-	Sex       string     `json:"sex"`
-	Vocation  string     `json:"vocation,omitempty"`
-	Level     int        `json:"level,omitempty"`
-	Residence string     `json:"residence,omitempty"`
-	Guild     string     `json:"guild,omitempty"`
-	GuildRank string     `json:"guild_rank,omitempty"`
-	GuildURL  string     `json:"guild_url,omitempty"`
-	LastLogin *time.Time `json:"last_login,omitempty"`
-	IsPremium bool       `json:"is_premium"`
-	Country   string     `json:"country,omitempty"`
-	Deaths    []Death    `json:"deaths,omitempty"`
+	Town      string     `json:"town,omitempty"`
+	PaidUntil *time.Time `json:"paid_until,omitempty"`
 }
 
+type AccountCharacter struct {
+	Name   string `json:"name"`
+	World  string `json:"world"`
+	Status string `json:"status"`
+}
+
+// Death is an entry of a character's death list. Date and KilledBy keep the
+// text as the site shows it.
 type Death struct {
-	Date     string `json:"date"`
-	Level    int    `json:"level"`
-	KilledBy string `json:"killed_by"`
+	Date     string     `json:"date"`
+	Time     *time.Time `json:"time,omitempty"`
+	Level    int        `json:"level"`
+	KilledBy string     `json:"killed_by"`
+	Killers  []Killer   `json:"killers,omitempty"`
+	// PvP is set when a player took part in the kill, Unjustified when the
+	// site marks the kill as unjustified.
+	PvP         bool `json:"pvp"`
+	Unjustified bool `json:"unjustified"`
 }
 
+// Killer is a player or monster named in a death.
+type Killer struct {
+	Name   string `json:"name"`
+	Player bool   `json:"player"`
+}
@@ -1,14 +1,40 @@
 package types
 
+import "time"
+
 type Guild struct {
-	GuildID int           `json:"guild_id"`
+	GuildID     int        `json:"guild_id"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Founded     *time.Time `json:"founded,omitempty"`
+	LogoURL     string     `json:"logo_url,omitempty"`
+	// Ranks lists the guild's ranks from highest to lowest.
+	Ranks   []string      `json:"ranks,omitempty"`
 	Members []GuildMember `json:"members"`
+	Invites []GuildInvite `json:"invites,omitempty"`
 }
 
 type GuildMember struct {
-	Rank     string `json:"rank"`
-	Name     string `json:"name"`
-	Vocation string `json:"vocation"`
-	Level    int    `json:"level"`
-	Status   string `json:"status"`
+	Rank     string     `json:"rank"`
+	Name     string     `json:"name"`
+	Title    string     `json:"title,omitempty"`
+	Vocation string     `json:"vocation"`
+	Level    int        `json:"level"`
+	Status   string     `json:"status"`
+	Joined   *time.Time `json:"joined,omitempty"`
+}
+
+// GuildInvite is a character invited to join a guild.
+type GuildInvite struct {
+	Name      string     `json:"name"`
+	InvitedAt *time.Time `json:"invited_at,omitempty"`
+}
+
+// GuildSummary is a guild as the site's guild list shows it.
+type GuildSummary struct {
+	GuildID     int    `json:"guild_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	LogoURL     string `json:"logo_url,omitempty"`
+	Members     int    `json:"members"`
 }
@@ -8,3 +8,9 @@ type Insomniac struct {
 	Level      int    `json:"level"`
 	TimeOnline string `json:"time_online"`
 }
+
+type InsomniacList struct {
+	Insomniacs  []Insomniac   `json:"insomniacs"`
+	Pages       int           `json:"pages"`
+	FailedPages []PageFailure `json:"failed_pages,omitempty"`
+}
@@ -0,0 +1,7 @@
+package types
+
+// PageFailure records a page of a multi-page list that could not be scraped.
+type PageFailure struct {
+	Page  int    `json:"page"`
+	Error string `json:"error"`
+}
@@ -7,3 +7,9 @@ type PowerGamer struct {
 	Level    int    `json:"level"`
 	Today    int    `json:"today"`
 }
+
+type PowerGamerList struct {
+	PowerGamers []PowerGamer  `json:"power_gamers"`
+	Pages       int           `json:"pages"`
+	FailedPages []PageFailure `json:"failed_pages,omitempty"`
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec turns values into bytes and back. Format identifies the codec in
+// the header of every value it encodes, so entries stay readable after the
+// configured codec changes; an ID must never be reused for another format.
+type Codec interface {
+	Format() byte
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// DefaultCompressThreshold is the encoded size from which values are
+// compressed with zstd.
+const DefaultCompressThreshold = 1024
+
+// Stored values start with headerMagic and a format byte: the codec's
+// Format, with flagZstd set if the payload is compressed. Values written
+// before the header existed are plain JSON, which never starts with a zero
+// byte.
+const (
+	headerMagic byte = 0x00
+	flagZstd    byte = 0x80
+)
+
+var (
+	// JSONCodec encodes values with encoding/json.
+	JSONCodec Codec = jsonCodec{}
+	// CBORCodec encodes values as CBOR (RFC 8949), honouring json struct
+	// tags. It is the default.
+	CBORCodec Codec = cborCodec{}
+)
+
+var errUnknownFormat = errors.New("unknown cache value format")
+
+type jsonCodec struct{}
+
+func (jsonCodec) Format() byte                               { return 1 }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// cborEncMode keeps timestamps as RFC 3339 strings with their time zone and
+// sub-second precision, as JSON does.
+var cborEncMode, _ = cbor.EncOptions{Time: cbor.TimeRFC3339Nano}.EncMode()
+
+type cborCodec struct{}
+
+func (cborCodec) Format() byte                               { return 2 }
+func (cborCodec) Marshal(v interface{}) ([]byte, error)      { return cborEncMode.Marshal(v) }
+func (cborCodec) Unmarshal(data []byte, v interface{}) error { return cbor.Unmarshal(data, v) }
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// encode marshals value with the client's codec behind a format header,
+// compressing it if compress is set and it reaches the threshold.
+func (c *Client) encode(value interface{}, compress bool) ([]byte, error) {
+	payload, err := c.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	format := c.codec.Format()
+	if compress && c.compressThreshold > 0 && len(payload) >= c.compressThreshold {
+		payload = zstdEncoder.EncodeAll(payload, nil)
+		format |= flagZstd
+	}
+
+	data := make([]byte, 0, 2+len(payload))
+	data = append(data, headerMagic, format)
+	return append(data, payload...), nil
+}
+
+// decode reverses encode with whichever known codec the header names.
+func (c *Client) decode(data []byte, dest interface{}) error {
+	if len(data) == 0 || data[0] != headerMagic {
+		return json.Unmarshal(data, dest)
+	}
+	if len(data) < 2 {
+		return errUnknownFormat
+	}
+
+	format, payload := data[1], data[2:]
+	if format&flagZstd != 0 {
+		var err error
+		if payload, err = zstdDecoder.DecodeAll(payload, nil); err != nil {
+			return fmt.Errorf("failed to decompress value: %w", err)
+		}
+		format &^= flagZstd
+	}
+
+	for _, codec := range []Codec{c.codec, CBORCodec, JSONCodec} {
+		if codec.Format() == format {
+			return codec.Unmarshal(payload, dest)
+		}
+	}
+	return fmt.Errorf("%w %d", errUnknownFormat, format)
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type codecSample struct {
+	Name   string    `json:"name"`
+	Level  int       `json:"level"`
+	Notes  []string  `json:"notes"`
+	SeenAt time.Time `json:"seen_at"`
+}
+
+func TestEntryRoundTripCompressed(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	c := New(backend, time.Minute)
+
+	seenAt := time.Date(2025, 3, 1, 12, 30, 15, 123456789, time.FixedZone("CET", 3600))
+	value := codecSample{Name: "Oten", Level: 250, Notes: make([]string, 200), SeenAt: seenAt}
+	for i := range value.Notes {
+		value.Notes[i] = "killed by a dragon lord"
+	}
+
+	if _, err := c.SetEntry(ctx, "character:Oten", value, TTL{Soft: time.Minute, Hard: time.Hour}); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	raw, err := backend.Get(ctx, "character:Oten")
+	if err != nil {
+		t.Fatalf("backend.Get() error = %v", err)
+	}
+	if raw[0] != headerMagic || raw[1] != CBORCodec.Format()|flagZstd {
+		t.Errorf("header = %x, want CBOR with zstd", raw[:2])
+	}
+	if len(raw) > 1024 {
+		t.Errorf("stored %d bytes, want compressed below 1024", len(raw))
+	}
+
+	var got codecSample
+	if _, err := c.GetEntry(ctx, "character:Oten", &got); err != nil {
+		t.Fatalf("GetEntry() error = %v", err)
+	}
+	if got.Name != value.Name || got.Level != value.Level || len(got.Notes) != len(value.Notes) {
+		t.Errorf("GetEntry() = %+v, want %+v", got, value)
+	}
+	if !got.SeenAt.Equal(seenAt) || got.SeenAt.Format(time.RFC3339Nano) != seenAt.Format(time.RFC3339Nano) {
+		t.Errorf("SeenAt = %v, want %v", got.SeenAt, seenAt)
+	}
+}
+
+func TestGetReadsLegacyJSON(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	c := New(backend, time.Minute)
+
+	backend.Set(ctx, "character:Oten", []byte(`{"name":"Oten","level":250}`), time.Minute)
+
+	var got codecSample
+	if err := c.Get(ctx, "character:Oten", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "Oten" || got.Level != 250 {
+		t.Errorf("Get() = %+v", got)
+	}
+}
+
+func TestGetReadsOtherCodec(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+
+	writer := New(backend, time.Minute, WithCodec(JSONCodec), WithCompressThreshold(0))
+	if err := writer.Set(ctx, "guild:1", codecSample{Name: "Red Rose"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	raw, _ := backend.Get(ctx, "guild:1")
+	if !bytes.HasPrefix(raw, []byte{headerMagic, JSONCodec.Format(), '{'}) {
+		t.Errorf("stored %q, want uncompressed JSON behind a header", raw)
+	}
+
+	var got codecSample
+	if err := New(backend, time.Minute).Get(ctx, "guild:1", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "Red Rose" {
+		t.Errorf("Get() = %+v", got)
+	}
+}
+
+func TestDecodeUnknownFormat(t *testing.T) {
+	c := New(NewMemoryBackend(10, 0), time.Minute)
+
+	var got codecSample
+	err := c.decode([]byte{headerMagic, 0x7f, 0x01}, &got)
+	if !errors.Is(err, errUnknownFormat) || !strings.Contains(err.Error(), "127") {
+		t.Errorf("decode() error = %v, want unknown format 127", err)
+	}
+}
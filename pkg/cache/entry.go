@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// TTL is how long an entry is served as fresh (Soft) and how long it is kept
+// at all (Hard). Between the two the entry is stale: still served, but due
+// for a refresh.
+type TTL struct {
+	Soft time.Duration
+	Hard time.Duration
+}
+
+// Meta describes a cached entry. StoredAt is when the value was scraped,
+// Sources the upstream URLs it was built from and Version the schema version
+// of the client that stored it, see WithVersion.
+type Meta struct {
+	StoredAt   time.Time `json:"stored_at"`
+	FreshUntil time.Time `json:"fresh_until"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Sources    []string  `json:"sources,omitempty"`
+	Version    int       `json:"version"`
+}
+
+// Stale reports whether the entry is past its soft TTL at now.
+func (m Meta) Stale(now time.Time) bool {
+	return now.After(m.FreshUntil)
+}
+
+// envelope is how entries written by SetEntry are stored. Value is encoded
+// separately, with its own format header, so it can be decoded into the
+// caller's type.
+type envelope struct {
+	Meta
+	Value []byte `json:"value"`
+}
+
+// GetEntry reads an entry written by SetEntry into dest and returns its
+// metadata. Values stored without an envelope are dropped and count as a
+// miss. So do entries of another version, which are left for the next write
+// to replace: during a deploy, instances of both versions share the cache.
+func (c *Client) GetEntry(ctx context.Context, key string, dest interface{}) (_ Meta, err error) {
+	defer func() { observeLookup(ctx, key, err) }()
+
+	if c.l1 != nil {
+		if meta, ok := c.l1.get(ctx, key, dest); ok {
+			return meta, nil
+		}
+	}
+
+	var entry envelope
+	if err := c.get(ctx, key, &entry); err != nil {
+		return Meta{}, err
+	}
+
+	if entry.Version != c.version {
+		slog.DebugContext(ctx, "outdated cache entry", "key", key, "version", entry.Version, "want", c.version)
+		return Meta{}, ErrCacheMiss
+	}
+
+	if entry.Value == nil || c.decode(entry.Value, dest) != nil {
+		slog.WarnContext(ctx, "unreadable cache entry, invalidating", "key", key)
+		_ = c.Delete(ctx, key) // Best effort delete
+		return Meta{}, ErrCacheMiss
+	}
+
+	if c.l1 != nil {
+		c.l1.set(key, entry.Meta, dest)
+	}
+	return entry.Meta, nil
+}
+
+// SetEntry stores value, built from the upstream URLs in sources, under key
+// along with its metadata, which it returns. The entry is dropped once
+// ttl.Hard has passed.
+func (c *Client) SetEntry(ctx context.Context, key string, value interface{}, ttl TTL, sources ...string) (Meta, error) {
+	// The envelope as a whole gets compressed.
+	data, err := c.encode(value, false)
+	if err != nil {
+		return Meta{}, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	now := time.Now().UTC()
+	meta := Meta{
+		StoredAt:   now,
+		FreshUntil: now.Add(ttl.Soft),
+		ExpiresAt:  now.Add(ttl.Hard),
+		Sources:    sources,
+		Version:    c.version,
+	}
+
+	if err := c.SetWithTTL(ctx, key, envelope{Meta: meta, Value: data}, ttl.Hard); err != nil {
+		return Meta{}, err
+	}
+
+	if c.l1 != nil {
+		c.l1.set(key, meta, value)
+	}
+	return meta, nil
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// FallbackBackend uses a primary backend and switches to a fallback one for
+// as long as the primary is failing, so the API keeps caching (per
+// instance) through a Valkey outage. Every operation tries the primary first,
+// which is how it notices the primary is back. A nil primary runs on the
+// fallback alone.
+type FallbackBackend struct {
+	primary  Backend
+	fallback Backend
+	degraded atomic.Bool
+}
+
+func NewFallbackBackend(primary, fallback Backend) *FallbackBackend {
+	b := &FallbackBackend{primary: primary, fallback: fallback}
+	b.degraded.Store(primary == nil)
+	return b
+}
+
+// Degraded reports whether the last operation had to use the fallback.
+func (b *FallbackBackend) Degraded() bool {
+	return b.degraded.Load()
+}
+
+// failed reports whether err means the primary is unusable, recording the
+// switch to or from the fallback.
+func (b *FallbackBackend) failed(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up; that says nothing about the primary.
+		return false
+	}
+	if err == nil || errors.Is(err, ErrCacheMiss) || errors.Is(err, ErrLocked) {
+		if b.degraded.Swap(false) {
+			slog.InfoContext(ctx, "cache backend recovered")
+		}
+		return false
+	}
+
+	if !b.degraded.Swap(true) {
+		slog.WarnContext(ctx, "cache backend failing, using in-process fallback", "error", err)
+	}
+	return true
+}
+
+func (b *FallbackBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	if b.primary != nil {
+		value, err := b.primary.Get(ctx, key)
+		if !b.failed(ctx, err) {
+			return value, err
+		}
+	}
+	return b.fallback.Get(ctx, key)
+}
+
+func (b *FallbackBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if b.primary != nil {
+		if err := b.primary.Set(ctx, key, value, ttl); !b.failed(ctx, err) {
+			return nil
+		}
+	}
+	return b.fallback.Set(ctx, key, value, ttl)
+}
+
+func (b *FallbackBackend) Delete(ctx context.Context, keys ...string) error {
+	// The fallback may hold a copy written during an outage.
+	_ = b.fallback.Delete(ctx, keys...)
+
+	if b.primary != nil {
+		if err := b.primary.Delete(ctx, keys...); !b.failed(ctx, err) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (b *FallbackBackend) Lock(ctx context.Context, name string, ttl time.Duration) (func(), error) {
+	if b.primary != nil {
+		unlock, err := b.primary.Lock(ctx, name, ttl)
+		if !b.failed(ctx, err) {
+			return unlock, err
+		}
+	}
+	return b.fallback.Lock(ctx, name, ttl)
+}
+
+func (b *FallbackBackend) Close() {
+	if b.primary != nil {
+		b.primary.Close()
+	}
+	b.fallback.Close()
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultL1TTL is how long an L1 keeps its copy of an entry, see WithL1. It
+// must stay well below the shortest TTL used with SetEntry, since it bounds
+// how long an instance may keep serving an entry that was replaced, deleted
+// or expired in the backend.
+const DefaultL1TTL = 5 * time.Second
+
+// l1 keeps recently read or written entries in process memory, already
+// decoded, so hot keys skip both the backend round trip and the decode.
+// Copies live for at most ttl, so instances converge on the backend without
+// needing invalidation messages.
+type l1 struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	now        func() time.Time
+}
+
+type l1Entry struct {
+	key       string
+	meta      Meta
+	value     reflect.Value
+	expiresAt time.Time
+}
+
+func newL1(maxEntries int, ttl time.Duration) *l1 {
+	return &l1{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// get copies the entry for key into dest if there is one of dest's type.
+func (c *l1) get(ctx context.Context, key string, dest interface{}) (Meta, bool) {
+	target := reflect.ValueOf(dest)
+	entry, ok := c.lookup(key)
+	hit := ok && target.Kind() == reflect.Pointer && entry.value.Type() == target.Type().Elem()
+	observeL1Lookup(ctx, key, hit)
+	if !hit {
+		return Meta{}, false
+	}
+
+	target.Elem().Set(entry.value)
+	return entry.meta, true
+}
+
+func (c *l1) lookup(key string) (*l1Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*l1Entry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// set keeps a copy of value, which may be a pointer to it, until the L1 TTL
+// or meta.ExpiresAt, whichever comes first. The copy is shallow: slices and
+// pointers inside it are shared with every later reader, which must treat
+// them as read-only.
+func (c *l1) set(key string, meta Meta, value interface{}) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return
+	}
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	now := c.now()
+	expiresAt := now.Add(c.ttl)
+	if !meta.ExpiresAt.IsZero() && meta.ExpiresAt.Before(expiresAt) {
+		expiresAt = meta.ExpiresAt
+	}
+
+	c.entries[key] = c.lru.PushFront(&l1Entry{key: key, meta: meta, value: copied, expiresAt: expiresAt})
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *l1) delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *l1) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*l1Entry)
+	delete(c.entries, entry.key)
+}
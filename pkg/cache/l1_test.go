@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestL1CapsTTL(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	ttl := TTL{Soft: time.Minute, Hard: time.Hour}
+
+	c := New(backend, time.Minute, WithL1(10, 5*time.Second))
+	now := time.Now()
+	c.l1.now = func() time.Time { return now }
+
+	if _, err := c.SetEntry(ctx, "whoisonline:name", "v1", ttl); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	// Another instance replaces the entry in the backend.
+	New(backend, time.Minute).SetEntry(ctx, "whoisonline:name", "v2", ttl)
+
+	var got string
+	if _, err := c.GetEntry(ctx, "whoisonline:name", &got); err != nil || got != "v1" {
+		t.Errorf("GetEntry() = %q, %v, want L1 copy", got, err)
+	}
+
+	now = now.Add(5 * time.Second)
+	if _, err := c.GetEntry(ctx, "whoisonline:name", &got); err != nil || got != "v2" {
+		t.Errorf("GetEntry() after L1 TTL = %q, %v, want value from the backend", got, err)
+	}
+}
+
+func TestL1ServesDecodedCopies(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	c := New(backend, time.Minute, WithL1(10, time.Minute))
+
+	value := &codecSample{Name: "Oten", Level: 250}
+	if _, err := c.SetEntry(ctx, "character:oten", value, TTL{Soft: time.Minute, Hard: time.Hour}); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+	value.Level = 1
+
+	// Garbage in the backend shows the hit neither reads nor decodes it.
+	backend.Set(ctx, "character:oten", []byte{headerMagic, 0x7f}, time.Hour)
+
+	var got codecSample
+	if _, err := c.GetEntry(ctx, "character:oten", &got); err != nil || got.Name != "Oten" || got.Level != 250 {
+		t.Fatalf("GetEntry() = %+v, %v, want the stored copy", got, err)
+	}
+	got.Level = 2
+
+	var again codecSample
+	if _, err := c.GetEntry(ctx, "character:oten", &again); err != nil || again.Level != 250 {
+		t.Errorf("GetEntry() = %+v, %v, want a copy unaffected by the first reader", again, err)
+	}
+}
+
+func TestL1Delete(t *testing.T) {
+	ctx := context.Background()
+	c := New(NewMemoryBackend(10, 0), time.Minute, WithL1(10, time.Minute))
+
+	c.SetEntry(ctx, "notfound:character:nobody", true, TTL{Soft: time.Minute, Hard: time.Minute})
+	if err := c.Delete(ctx, "notfound:character:nobody"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	var marker bool
+	if _, err := c.GetEntry(ctx, "notfound:character:nobody", &marker); err != ErrCacheMiss {
+		t.Errorf("GetEntry() after Delete error = %v, want ErrCacheMiss", err)
+	}
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bounds for a MemoryBackend created with zero limits.
+const (
+	DefaultMemoryEntries       = 10000
+	DefaultMemoryBytes   int64 = 64 << 20
+)
+
+// MemoryBackend keeps entries in process memory, evicting the least recently
+// used ones once it holds more than maxEntries or maxBytes of values. Its
+// locks only cover this process.
+type MemoryBackend struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	entries    map[string]*list.Element
+	lru        *list.List // front is most recently used
+	locks      map[string]time.Time
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemoryBackend(maxEntries int, maxBytes int64) *MemoryBackend {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryEntries
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMemoryBytes
+	}
+
+	return &MemoryBackend{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		locks:      make(map[string]time.Time),
+		now:        time.Now,
+	}
+}
+
+func (b *MemoryBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elem, ok := b.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if !b.now().Before(entry.expiresAt) {
+		b.remove(elem)
+		return nil, ErrCacheMiss
+	}
+
+	b.lru.MoveToFront(elem)
+	return entry.value, nil
+}
+
+func (b *MemoryBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elem, ok := b.entries[key]; ok {
+		b.remove(elem)
+	}
+	if int64(len(value)) > b.maxBytes {
+		return nil
+	}
+
+	b.entries[key] = b.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: b.now().Add(ttl)})
+	b.size += int64(len(value))
+	for b.lru.Len() > b.maxEntries || b.size > b.maxBytes {
+		b.remove(b.lru.Back())
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Delete(ctx context.Context, keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := b.entries[key]; ok {
+			b.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBackend) remove(elem *list.Element) {
+	entry := b.lru.Remove(elem).(*memoryEntry)
+	delete(b.entries, entry.key)
+	b.size -= int64(len(entry.value))
+}
+
+// Len returns the number of entries held, including expired ones not yet
+// evicted.
+func (b *MemoryBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lru.Len()
+}
+
+func (b *MemoryBackend) Keys(ctx context.Context, prefix string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	var keys []string
+	for key, elem := range b.entries {
+		if strings.HasPrefix(key, prefix) && now.Before(elem.Value.(*memoryEntry).expiresAt) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (b *MemoryBackend) Lock(ctx context.Context, name string, ttl time.Duration) (func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if expiresAt, ok := b.locks[name]; ok && now.Before(expiresAt) {
+		return nil, ErrLocked
+	}
+
+	expiresAt := now.Add(ttl)
+	b.locks[name] = expiresAt
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// Leave the lock alone if it expired and was taken again.
+		if b.locks[name] == expiresAt {
+			delete(b.locks, name)
+		}
+	}, nil
+}
+
+func (b *MemoryBackend) Close() {}
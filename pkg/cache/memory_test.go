@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryBackendExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	b := NewMemoryBackend(10, 0)
+	b.now = func() time.Time { return now }
+
+	if err := b.Set(ctx, "character:Oten", []byte("{}"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := b.Get(ctx, "character:Oten"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := b.Get(ctx, "character:Oten"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() after TTL error = %v, want ErrCacheMiss", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Len() = %d, want expired entry evicted", b.Len())
+	}
+}
+
+func TestMemoryBackendEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend(2, 0)
+
+	b.Set(ctx, "a", []byte("1"), time.Minute)
+	b.Set(ctx, "b", []byte("2"), time.Minute)
+	b.Get(ctx, "a")
+	b.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, err := b.Get(ctx, "b"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(b) error = %v, want b evicted", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := b.Get(ctx, key); err != nil {
+			t.Errorf("Get(%s) error = %v", key, err)
+		}
+	}
+}
+
+func TestMemoryBackendLock(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend(10, 0)
+
+	unlock, err := b.Lock(ctx, "guild:386", time.Minute)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if _, err := b.Lock(ctx, "guild:386", time.Minute); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Lock() error = %v, want ErrLocked", err)
+	}
+
+	unlock()
+	if _, err := b.Lock(ctx, "guild:386", time.Minute); err != nil {
+		t.Errorf("Lock() after unlock error = %v", err)
+	}
+}
+
+func TestFallbackBackend(t *testing.T) {
+	ctx := context.Background()
+	primary := &failingBackend{Backend: NewMemoryBackend(10, 0)}
+	b := NewFallbackBackend(primary, NewMemoryBackend(10, 0))
+
+	primary.err = errors.New("connection refused")
+	if err := b.Set(ctx, "guild:386", []byte("{}"), time.Minute); err != nil {
+		t.Fatalf("Set() during outage error = %v", err)
+	}
+	if _, err := b.Get(ctx, "guild:386"); err != nil {
+		t.Errorf("Get() during outage error = %v, want value from fallback", err)
+	}
+	if !b.Degraded() {
+		t.Error("Degraded() = false during outage")
+	}
+
+	primary.err = nil
+	if _, err := b.Get(ctx, "guild:386"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() after recovery error = %v, want miss from primary", err)
+	}
+	if b.Degraded() {
+		t.Error("Degraded() = true after recovery")
+	}
+}
+
+type failingBackend struct {
+	Backend
+	err error
+}
+
+func (b *failingBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.Backend.Get(ctx, key)
+}
+
+func (b *failingBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if b.err != nil {
+		return b.err
+	}
+	return b.Backend.Set(ctx, key, value, ttl)
+}
+
+func TestMemoryBackendByteLimit(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBackend(10, 4)
+
+	b.Set(ctx, "a", []byte("12"), time.Minute)
+	b.Set(ctx, "b", []byte("34"), time.Minute)
+	b.Set(ctx, "c", []byte("5"), time.Minute)
+
+	if _, err := b.Get(ctx, "a"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(a) error = %v, want a evicted", err)
+	}
+	b.Set(ctx, "huge", []byte("too large"), time.Minute)
+	if b.Len() != 2 {
+		t.Errorf("Len() = %d, want oversized value skipped", b.Len())
+	}
+}
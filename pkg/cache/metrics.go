@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	meter = otel.Meter("github.com/ethaan/miracle74-api/pkg/cache")
+
+	lookups = must(meter.Int64Counter("cache.lookups",
+		metric.WithDescription("Cache reads by key family and result (hit, miss or error)"),
+	))
+	l1Lookups = must(meter.Int64Counter("cache.l1.lookups",
+		metric.WithDescription("In-process L1 reads by key family and result (hit or miss)"),
+	))
+)
+
+func must[T any](instrument T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return instrument
+}
+
+// observeLookup counts a Get or GetEntry of key that returned err. Entries
+// GetEntry rejects, such as those of another version, count as misses.
+func observeLookup(ctx context.Context, key string, err error) {
+	result := "hit"
+	switch {
+	case errors.Is(err, ErrCacheMiss):
+		result = "miss"
+	case err != nil:
+		result = "error"
+	}
+
+	lookups.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("family", keyFamily(key)),
+		attribute.String("result", result),
+	))
+}
+
+// observeL1Lookup counts a read of key from a Client's L1, see WithL1.
+func observeL1Lookup(ctx context.Context, key string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	l1Lookups.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("family", keyFamily(key)),
+		attribute.String("result", result),
+	))
+}
+
+// keyFamily returns the part of key before the first colon, e.g. "character"
+// for "character:Oten", so metrics don't get a series per key.
+func keyFamily(key string) string {
+	family, _, _ := strings.Cut(key, ":")
+	return family
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOutdatedEntryCountsAsMiss(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	ttl := TTL{Soft: time.Minute, Hard: time.Hour}
+
+	if _, err := New(backend, time.Minute, WithVersion(1)).SetEntry(ctx, "character:Oten", "old", ttl); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	var got string
+	if _, err := New(backend, time.Minute, WithVersion(2)).GetEntry(ctx, "character:Oten", &got); err != ErrCacheMiss {
+		t.Fatalf("GetEntry() error = %v, want ErrCacheMiss", err)
+	}
+
+	counts := lookupCounts(t, reader)
+	if counts["miss"] != 1 || counts["hit"] != 0 {
+		t.Errorf("cache.lookups = %v, want one miss", counts)
+	}
+}
+
+// lookupCounts returns the cache.lookups counter by result.
+func lookupCounts(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "cache.lookups" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				result, _ := dp.Attributes.Value(attribute.Key("result"))
+				counts[result.AsString()] += dp.Value
+			}
+		}
+	}
+	return counts
+}
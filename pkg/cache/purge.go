@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// lockPrefix is where ValkeyBackend keeps its locks, which are never listed.
+const lockPrefix = "lock:"
+
+// ErrNotListable is returned by Keys and Outdated when the backend cannot
+// enumerate its keys.
+var ErrNotListable = errors.New("cache backend cannot list keys")
+
+// Lister is implemented by backends that can enumerate their keys.
+type Lister interface {
+	// Keys returns the unexpired keys starting with prefix.
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Keys returns the keys starting with prefix, leaving out locks.
+func (c *Client) Keys(ctx context.Context, prefix string) ([]string, error) {
+	lister, ok := c.backend.(Lister)
+	if !ok {
+		return nil, ErrNotListable
+	}
+
+	keys, err := lister.Keys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := keys[:0]
+	for _, key := range keys {
+		if !strings.HasPrefix(key, lockPrefix) {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
+
+// Outdated returns the keys starting with prefix whose entries were stored
+// by another version (see WithVersion) or cannot be read, i.e. the ones
+// GetEntry would no longer serve. Nothing is deleted.
+func (c *Client) Outdated(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := c.Keys(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var outdated []string
+	for _, key := range keys {
+		data, err := c.backend.Get(ctx, key)
+		if errors.Is(err, ErrCacheMiss) {
+			continue // Expired since it was listed
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var entry envelope
+		if c.decode(data, &entry) != nil || entry.Version != c.version {
+			outdated = append(outdated, key)
+		}
+	}
+	return outdated, nil
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetEntryIgnoresOtherVersions(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	ttl := TTL{Soft: time.Minute, Hard: time.Hour}
+
+	if _, err := New(backend, time.Minute, WithVersion(1)).SetEntry(ctx, "character:Oten", "old", ttl); err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+
+	c := New(backend, time.Minute, WithVersion(2))
+	var got string
+	if _, err := c.GetEntry(ctx, "character:Oten", &got); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("GetEntry() of version 1 error = %v, want ErrCacheMiss", err)
+	}
+
+	meta, err := c.SetEntry(ctx, "character:Oten", "new", ttl)
+	if err != nil {
+		t.Fatalf("SetEntry() error = %v", err)
+	}
+	if meta.Version != 2 {
+		t.Errorf("Version = %d, want 2", meta.Version)
+	}
+	if _, err := c.GetEntry(ctx, "character:Oten", &got); err != nil || got != "new" {
+		t.Errorf("GetEntry() = %q, %v, want new", got, err)
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 0)
+	ttl := TTL{Soft: time.Minute, Hard: time.Hour}
+
+	old := New(backend, time.Minute, WithVersion(1))
+	current := New(backend, time.Minute, WithVersion(2))
+
+	old.SetEntry(ctx, "character:Old", "x", ttl)
+	current.SetEntry(ctx, "character:Current", "x", ttl)
+	old.SetEntry(ctx, "guild:1", "x", ttl)
+	backend.Set(ctx, "character:Corrupt", []byte("not json"), time.Hour)
+	backend.Set(ctx, "lock:character:Old", []byte("token"), time.Hour)
+
+	keys, err := current.Outdated(ctx, "character:")
+	if err != nil {
+		t.Fatalf("Outdated() error = %v", err)
+	}
+	slices.Sort(keys)
+	if want := []string{"character:Corrupt", "character:Old"}; !slices.Equal(keys, want) {
+		t.Errorf("Outdated() = %v, want %v", keys, want)
+	}
+
+	all, err := current.Keys(ctx, "")
+	if err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+	if len(all) != 4 || slices.Contains(all, "lock:character:Old") {
+		t.Errorf("Keys() = %v, want the 4 entries without the lock", all)
+	}
+
+	if _, err := New(&failingBackend{}, time.Minute).Keys(ctx, ""); !errors.Is(err, ErrNotListable) {
+		t.Errorf("Keys() on an unlistable backend error = %v, want ErrNotListable", err)
+	}
+}
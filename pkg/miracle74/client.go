@@ -2,70 +2,205 @@ package miracle74
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"math/rand"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/ethaan/miracle74-api/internal/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/html"
 )
 
 const (
-	baseURL        = "https://miracle74.com"
-	defaultTimeout = 30 * time.Second
+	defaultBaseURL   = "https://miracle74.com"
+	defaultTimeout   = 30 * time.Second
+	defaultUserAgent = "Miracle74-API/0.1.0"
 )
 
+// browserHeader is sent instead of the default User-Agent for pages that
+// turn away obvious bots.
+var browserHeader = http.Header{
+	"User-Agent": {"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) " +
+		"AppleWebKit/537.36 (KHTML, like Gecko) " +
+		"Chrome/120.0.0.0 Safari/537.36"},
+	"Accept":          {"text/html,application/xhtml+xml"},
+	"Accept-Language": {"en-US,en;q=0.9"},
+	"Connection":      {"keep-alive"},
+}
+
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	userAgent  string
+	logger     *slog.Logger
+	scheduler  *Scheduler
+	retry      RetryPolicy
+	location   *time.Location
 }
 
-func NewClient() *Client {
-	return &Client{
+// NewClient returns a client for miracle74.com. Without WithScheduler the
+// client gets a private scheduler with the default limits.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
-		baseURL: baseURL,
+		baseURL:   defaultBaseURL,
+		userAgent: defaultUserAgent,
+		logger:    slog.Default(),
+		location:  defaultLocation,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.scheduler == nil {
+		c.scheduler = NewScheduler(SchedulerConfig{})
+	}
+	c.retry = c.retry.withDefaults()
+
+	return c
 }
 
-func (c *Client) ScrapeCharacter(name string) (*types.Character, error) {
+// fetch GETs the page identified by query and returns the response body.
+// Failed attempts are retried according to the client's RetryPolicy. Extra
+// header values replace the defaults.
+func (c *Client) fetch(ctx context.Context, query url.Values, header http.Header) (_ []byte, err error) {
+	ctx, span := tracer.Start(ctx, "miracle74.fetch", trace.WithAttributes(
+		attribute.String("miracle74.subtopic", query.Get("subtopic")),
+		attribute.String("miracle74.page", query.Get("page")),
+	))
+	defer func() { endSpan(span, err) }()
+
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse base URL: %w", err)
 	}
+	u.RawQuery = query.Encode()
+
+	for attempt := 1; ; attempt++ {
+		body, err := c.fetchOnce(ctx, u, header, attempt)
+		if err == nil {
+			span.SetAttributes(
+				attribute.Int("miracle74.attempts", attempt),
+				attribute.Int("http.response.body.size", len(body)),
+			)
+			addSource(ctx, u.String())
+			return body, nil
+		}
 
-	q := u.Query()
-	q.Set("subtopic", "characters")
-	q.Set("name", name)
-	u.RawQuery = q.Encode()
+		var retryAfter time.Duration
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			retryAfter = statusErr.RetryAfter
+		}
+
+		if !retryable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		rateLimited := errors.Is(err, ErrRateLimited)
+		if attempt >= c.retry.MaxAttempts || retryAfter > c.retry.MaxDelay {
+			c.logger.WarnContext(ctx, "giving up on upstream request",
+				"url", u.String(), "attempts", attempt, "error", err)
+			if rateLimited {
+				return nil, &RateLimitedError{Attempts: attempt, RetryAfter: retryAfter}
+			}
+			return nil, err
+		}
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		delay := c.retry.backoff(attempt, retryAfter)
+		observeRetry(ctx, query.Get("subtopic"), err)
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("miracle74.attempt", attempt),
+			attribute.String("miracle74.delay", delay.String()),
+			attribute.String("error", err.Error()),
+		))
+		c.logger.InfoContext(ctx, "retrying upstream request",
+			"url", u.String(), "attempt", attempt, "max_attempts", c.retry.MaxAttempts,
+			"delay", delay, "retry_after", retryAfter, "error", err)
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, transportError(err)
+		}
+	}
+}
+
+// fetchOnce makes a single request once the scheduler allows it.
+func (c *Client) fetchOnce(ctx context.Context, u *url.URL, header http.Header, attempt int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "Miracle74-API/0.1.0")
+	req.Header.Set("User-Agent", c.userAgent)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	release, err := c.scheduler.Acquire(ctx)
+	if err != nil {
+		return nil, transportError(err)
+	}
+	defer release()
+
+	query := u.Query()
+	logger := c.logger.With(
+		"subtopic", query.Get("subtopic"),
+		"page", query.Get("page"),
+		"attempt", attempt,
+	)
+	start := time.Now()
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch page: %w", err)
+		observeFetch(ctx, query.Get("subtopic"), 0, time.Since(start))
+		logger.DebugContext(ctx, "upstream request failed", "duration", time.Since(start), "error", err)
+		return nil, fmt.Errorf("failed to fetch page: %w", transportError(err))
 	}
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		observeFetch(ctx, query.Get("subtopic"), resp.StatusCode, time.Since(start))
+		logger.DebugContext(ctx, "upstream returned an error", "status", resp.StatusCode, "duration", time.Since(start))
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		observeFetch(ctx, query.Get("subtopic"), 0, time.Since(start))
+		return nil, fmt.Errorf("failed to read response body: %w", transportError(err))
 	}
 
-	character, err := c.parseCharacterHTML(body, name)
+	observeFetch(ctx, query.Get("subtopic"), resp.StatusCode, time.Since(start))
+	logger.DebugContext(ctx, "fetched upstream page",
+		"status", resp.StatusCode, "bytes", len(body), "duration", time.Since(start))
+	return body, nil
+}
+
+func (c *Client) ScrapeCharacter(ctx context.Context, name string) (*types.Character, error) {
+	q := url.Values{}
+	q.Set("subtopic", "characters")
+	q.Set("name", name)
+
+	body, err := c.fetch(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	character, err := c.parseCharacterHTML(ctx, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse character data: %w", err)
 	}
@@ -73,239 +208,171 @@ func (c *Client) ScrapeCharacter(name string) (*types.Character, error) {
 	return character, nil
 }
 
-func (c *Client) parseCharacterHTML(htmlContent []byte, name string) (*types.Character, error) {
+func (c *Client) parseCharacterHTML(ctx context.Context, htmlContent []byte) (_ *types.Character, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "characters")))
+	defer func() { endParse(ctx, span, "characters", err) }()
+
 	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	character, err := parseCharacterData(doc)
+	character, err := parseCharacterData(doc, c.location)
 	if err != nil {
-		fmt.Printf("DEBUG: Parser error: %v\n", err)
 		return nil, fmt.Errorf("failed to extract character data: %w", err)
 	}
 
-	fmt.Printf("DEBUG: Parsed character: %+v\n", character)
+	observeRows(ctx, span, "characters", len(character.Deaths))
+	c.logger.DebugContext(ctx, "parsed character", "name", character.Name, "deaths", len(character.Deaths))
 	return character, nil
 }
 
-func (c *Client) ScrapePowerGamers(includeAll bool, list string, vocation string) ([]types.PowerGamer, error) {
-	var allPowerGamers []types.PowerGamer
-
-	maxPages := 1
-	if includeAll {
-		maxPages = 10
-	}
-
-	for page := 1; page <= maxPages; page++ {
-		fmt.Printf("Scraping power gamers page %d (list=%s, vocation=%s)...\n", page, list, vocation)
-
-		u, err := url.Parse(c.baseURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse base URL: %w", err)
-		}
+func (c *Client) ScrapePowerGamers(ctx context.Context, includeAll bool, list string, vocation string) (_ *types.PowerGamerList, err error) {
+	ctx, span := tracer.Start(ctx, "miracle74.ScrapePowerGamers", trace.WithAttributes(
+		attribute.Bool("miracle74.include_all", includeAll),
+		attribute.String("miracle74.list", list),
+		attribute.String("miracle74.vocation", vocation),
+	))
+	defer func() { endSpan(span, err) }()
 
-		q := u.Query()
+	set, err := fetchPages(ctx, includeAll, func(ctx context.Context, page int) ([]types.PowerGamer, int, error) {
+		q := url.Values{}
 		q.Set("subtopic", "powergamers")
 		q.Set("list", list)
 		if vocation != "" {
 			q.Set("vocation", vocation)
 		}
 		q.Set("page", fmt.Sprintf("%d", page))
-		u.RawQuery = q.Encode()
-
-		fmt.Printf("DEBUG: Requesting URL: %s\n", u.String())
-
-		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request for page %d: %w", page, err)
-		}
-
-		req.Header.Set("User-Agent",
-			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) "+
-				"AppleWebKit/537.36 (KHTML, like Gecko) "+
-				"Chrome/120.0.0.0 Safari/537.36")
-		req.Header.Set("Accept", "text/html,application/xhtml+xml")
-		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-		req.Header.Set("Connection", "keep-alive")
-
-		fmt.Printf("DEBUG: Starting HTTP request for page %d...\n", page)
-
-		var resp *http.Response
-		// Simple retry logic for rate limiting (429)
-		for attempt := 1; attempt <= 3; attempt++ {
-			resp, err = c.httpClient.Do(req)
-			if err != nil {
-				fmt.Printf("DEBUG: HTTP request failed for page %d: %v\n", page, err)
-				return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
-			}
-			fmt.Printf("DEBUG: HTTP request completed for page %d, status: %d (attempt %d)\n", page, resp.StatusCode, attempt)
-
-			if resp.StatusCode == 429 && attempt < 3 {
-				resp.Body.Close()
-				return nil, fmt.Errorf("rate limited by upstream")
-			}
-			break
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			return nil, fmt.Errorf("unexpected status code for page %d: %d", page, resp.StatusCode)
-		}
 
-		fmt.Printf("DEBUG: Reading response body for page %d...\n", page)
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		body, err := c.fetch(ctx, q, browserHeader)
 		if err != nil {
-			fmt.Printf("DEBUG: Failed to read body for page %d: %v\n", page, err)
-			return nil, fmt.Errorf("failed to read response body for page %d: %w", page, err)
+			return nil, 0, err
 		}
-		fmt.Printf("DEBUG: Read %d bytes from page %d\n", len(body), page)
 
-		powerGamers, err := c.parsePowerGamersHTML(body)
+		powerGamers, lastPage, err := c.parsePowerGamersHTML(ctx, body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse power gamers data from page %d: %w", page, err)
+			return nil, 0, fmt.Errorf("failed to parse power gamers data: %w", err)
 		}
-
-		allPowerGamers = append(allPowerGamers, powerGamers...)
-
-		if page < maxPages {
-			fmt.Printf("DEBUG: Waiting 5 seconds before fetching next page...\n")
-			time.Sleep(time.Duration(4+rand.Intn(5)) * time.Second)
-		}
-	}
-
-	fmt.Printf("Successfully scraped %d power gamers from %d page(s)\n", len(allPowerGamers), maxPages)
-	return allPowerGamers, nil
+		return powerGamers, lastPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	powerGamers := set.all()
+	sort.SliceStable(powerGamers, func(i, j int) bool { return powerGamers[i].Rank < powerGamers[j].Rank })
+
+	span.SetAttributes(
+		attribute.Int("miracle74.rows", len(powerGamers)),
+		attribute.Int("miracle74.pages", set.pages),
+		attribute.Int("miracle74.failed_pages", len(set.failed)),
+	)
+	c.logger.InfoContext(ctx, "scraped power gamers",
+		"list", list, "vocation", vocation, "rows", len(powerGamers), "pages", set.pages, "failed_pages", len(set.failed))
+	return &types.PowerGamerList{
+		PowerGamers: powerGamers,
+		Pages:       set.pages,
+		FailedPages: set.failed,
+	}, nil
 }
 
-func (c *Client) parsePowerGamersHTML(htmlContent []byte) ([]types.PowerGamer, error) {
+func (c *Client) parsePowerGamersHTML(ctx context.Context, htmlContent []byte) (_ []types.PowerGamer, _ int, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "powergamers")))
+	defer func() { endParse(ctx, span, "powergamers", err) }()
+
 	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, 0, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	powerGamers, err := parsePowerGamersData(doc)
+	powerGamers, err := parsePowerGamersData(doc, c.logger)
 	if err != nil {
-		fmt.Printf("DEBUG: Parser error: %v\n", err)
-		return nil, fmt.Errorf("failed to extract power gamers data: %w", err)
+		return nil, 0, fmt.Errorf("failed to extract power gamers data: %w", err)
 	}
 
-	fmt.Printf("DEBUG: Parsed %d power gamers from page\n", len(powerGamers))
-	return powerGamers, nil
+	lastPage := parseLastPage(doc, "powergamers")
+	span.SetAttributes(attribute.Int("miracle74.last_page", lastPage))
+	observeRows(ctx, span, "powergamers", len(powerGamers))
+	c.logger.DebugContext(ctx, "parsed power gamers page", "rows", len(powerGamers), "last_page", lastPage)
+	return powerGamers, lastPage, nil
 }
 
-func (c *Client) ScrapeInsomniacs(includeAll bool) ([]types.Insomniac, error) {
-	var allInsomniacs []types.Insomniac
-
-	maxPages := 1
-	if includeAll {
-		maxPages = 10
-	}
-
-	for page := 1; page <= maxPages; page++ {
-		fmt.Printf("Scraping insomniacs page %d...\n", page)
-
-		u, err := url.Parse(c.baseURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse base URL: %w", err)
-		}
+func (c *Client) ScrapeInsomniacs(ctx context.Context, includeAll bool) (_ *types.InsomniacList, err error) {
+	ctx, span := tracer.Start(ctx, "miracle74.ScrapeInsomniacs", trace.WithAttributes(
+		attribute.Bool("miracle74.include_all", includeAll),
+	))
+	defer func() { endSpan(span, err) }()
 
-		q := u.Query()
+	set, err := fetchPages(ctx, includeAll, func(ctx context.Context, page int) ([]types.Insomniac, int, error) {
+		q := url.Values{}
 		q.Set("subtopic", "insomniacs")
 		q.Set("page", fmt.Sprintf("%d", page))
-		u.RawQuery = q.Encode()
 
-		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		body, err := c.fetch(ctx, q, nil)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request for page %d: %w", page, err)
+			return nil, 0, err
 		}
 
-		req.Header.Set("User-Agent", "Miracle74-API/0.1.0")
-
-		resp, err := c.httpClient.Do(req)
+		insomniacs, lastPage, err := c.parseInsomniacsHTML(ctx, body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+			return nil, 0, fmt.Errorf("failed to parse insomniacs data: %w", err)
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			return nil, fmt.Errorf("unexpected status code for page %d: %d", page, resp.StatusCode)
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body for page %d: %w", page, err)
-		}
-
-		insomniacs, err := c.parseInsomniacsHTML(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse insomniacs data from page %d: %w", page, err)
-		}
-
-		allInsomniacs = append(allInsomniacs, insomniacs...)
-
-		if page < maxPages {
-			time.Sleep(1 * time.Second)
-		}
-	}
-
-	fmt.Printf("Successfully scraped %d insomniacs from %d page(s)\n", len(allInsomniacs), maxPages)
-	return allInsomniacs, nil
+		return insomniacs, lastPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	insomniacs := set.all()
+	sort.SliceStable(insomniacs, func(i, j int) bool { return insomniacs[i].Rank < insomniacs[j].Rank })
+
+	span.SetAttributes(
+		attribute.Int("miracle74.rows", len(insomniacs)),
+		attribute.Int("miracle74.pages", set.pages),
+		attribute.Int("miracle74.failed_pages", len(set.failed)),
+	)
+	c.logger.InfoContext(ctx, "scraped insomniacs",
+		"rows", len(insomniacs), "pages", set.pages, "failed_pages", len(set.failed))
+	return &types.InsomniacList{
+		Insomniacs:  insomniacs,
+		Pages:       set.pages,
+		FailedPages: set.failed,
+	}, nil
 }
 
-func (c *Client) parseInsomniacsHTML(htmlContent []byte) ([]types.Insomniac, error) {
+func (c *Client) parseInsomniacsHTML(ctx context.Context, htmlContent []byte) (_ []types.Insomniac, _ int, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "insomniacs")))
+	defer func() { endParse(ctx, span, "insomniacs", err) }()
+
 	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, 0, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	insomniacs, err := parseInsomniacsData(doc)
+	insomniacs, err := parseInsomniacsData(doc, c.logger)
 	if err != nil {
-		fmt.Printf("DEBUG: Parser error: %v\n", err)
-		return nil, fmt.Errorf("failed to extract insomniacs data: %w", err)
+		return nil, 0, fmt.Errorf("failed to extract insomniacs data: %w", err)
 	}
 
-	fmt.Printf("DEBUG: Parsed %d insomniacs\n", len(insomniacs))
-	return insomniacs, nil
+	lastPage := parseLastPage(doc, "insomniacs")
+	span.SetAttributes(attribute.Int("miracle74.last_page", lastPage))
+	observeRows(ctx, span, "insomniacs", len(insomniacs))
+	c.logger.DebugContext(ctx, "parsed insomniacs page", "rows", len(insomniacs), "last_page", lastPage)
+	return insomniacs, lastPage, nil
 }
 
-func (c *Client) ScrapeGuild(guildID int) (*types.Guild, error) {
-	u, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
-	}
-
-	q := u.Query()
+func (c *Client) ScrapeGuild(ctx context.Context, guildID int) (*types.Guild, error) {
+	q := url.Values{}
 	q.Set("subtopic", "guilds")
 	q.Set("action", "show")
 	q.Set("guild", fmt.Sprintf("%d", guildID))
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "Miracle74-API/0.1.0")
 
-	resp, err := c.httpClient.Do(req)
+	body, err := c.fetch(ctx, q, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch page: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, err
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	guild, err := c.parseGuildHTML(body, guildID)
+	guild, err := c.parseGuildHTML(ctx, body, guildID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse guild data: %w", err)
 	}
@@ -313,82 +380,114 @@ func (c *Client) ScrapeGuild(guildID int) (*types.Guild, error) {
 	return guild, nil
 }
 
-func (c *Client) parseGuildHTML(htmlContent []byte, guildID int) (*types.Guild, error) {
+func (c *Client) parseGuildHTML(ctx context.Context, htmlContent []byte, guildID int) (_ *types.Guild, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "guilds")))
+	defer func() { endParse(ctx, span, "guilds", err) }()
+
 	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	guild, err := parseGuildData(doc, guildID)
+	guild, err := parseGuildData(doc, guildID, c.logger)
 	if err != nil {
-		fmt.Printf("DEBUG: Parser error: %v\n", err)
 		return nil, fmt.Errorf("failed to extract guild data: %w", err)
 	}
 
-	fmt.Printf("DEBUG: Parsed guild with %d members\n", len(guild.Members))
+	observeRows(ctx, span, "guilds", len(guild.Members))
+	c.logger.DebugContext(ctx, "parsed guild", "guild_id", guildID, "members", len(guild.Members))
 	return guild, nil
 }
 
-func (c *Client) ScrapeWhoIsOnline(order string) ([]types.OnlinePlayer, error) {
-	u, err := url.Parse(c.baseURL)
+// ScrapeGuilds returns every guild on the site's guild list.
+func (c *Client) ScrapeGuilds(ctx context.Context) ([]types.GuildSummary, error) {
+	q := url.Values{}
+	q.Set("subtopic", "guilds")
+
+	body, err := c.fetch(ctx, q, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		return nil, err
 	}
 
-	q := u.Query()
-	q.Set("subtopic", "whoisonline")
-	if order != "" {
-		q.Set("order", order)
+	guilds, err := c.parseGuildListHTML(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse guild list: %w", err)
 	}
-	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	return guilds, nil
+}
+
+func (c *Client) parseGuildListHTML(ctx context.Context, htmlContent []byte) (_ []types.GuildSummary, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "guildlist")))
+	defer func() { endParse(ctx, span, "guildlist", err) }()
+
+	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	req.Header.Set("User-Agent", "Miracle74-API/0.1.0")
-
-	resp, err := c.httpClient.Do(req)
+	guilds, err := parseGuildListData(doc, c.logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch page: %w", err)
+		return nil, fmt.Errorf("failed to extract guild list: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	observeRows(ctx, span, "guildlist", len(guilds))
+	c.logger.DebugContext(ctx, "parsed guild list", "guilds", len(guilds))
+	return guilds, nil
+}
+
+func (c *Client) ScrapeWhoIsOnline(ctx context.Context, order string) ([]types.OnlinePlayer, error) {
+	q := url.Values{}
+	q.Set("subtopic", "whoisonline")
+	if order != "" {
+		q.Set("order", order)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.fetch(ctx, q, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
-	onlinePlayers, err := c.parseWhoIsOnlineHTML(body)
+	onlinePlayers, err := c.parseWhoIsOnlineHTML(ctx, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse who is online data: %w", err)
 	}
 
-	fmt.Printf("Successfully scraped %d online players\n", len(onlinePlayers))
+	c.logger.DebugContext(ctx, "scraped who is online", "order", order, "rows", len(onlinePlayers))
 	return onlinePlayers, nil
 }
 
-func (c *Client) parseWhoIsOnlineHTML(htmlContent []byte) ([]types.OnlinePlayer, error) {
+func (c *Client) parseWhoIsOnlineHTML(ctx context.Context, htmlContent []byte) (_ []types.OnlinePlayer, err error) {
+	_, span := tracer.Start(ctx, "miracle74.parse", trace.WithAttributes(attribute.String("miracle74.subtopic", "whoisonline")))
+	defer func() { endParse(ctx, span, "whoisonline", err) }()
+
 	doc, err := html.Parse(bytes.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
 	}
 
-	onlinePlayers, err := parseWhoIsOnlineData(doc)
+	onlinePlayers, err := parseWhoIsOnlineData(doc, c.logger)
 	if err != nil {
-		fmt.Printf("DEBUG: Parser error: %v\n", err)
 		return nil, fmt.Errorf("failed to extract who is online data: %w", err)
 	}
 
-	fmt.Printf("DEBUG: Parsed %d online players\n", len(onlinePlayers))
+	observeRows(ctx, span, "whoisonline", len(onlinePlayers))
 	return onlinePlayers, nil
 }
 
+// sleep pauses for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // func (c *Client) saveHTMLForDebug(htmlContent []byte, name string) error {
 // 	publicDir := "public"
 
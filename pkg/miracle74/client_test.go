@@ -0,0 +1,197 @@
+package miracle74
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var record = flag.Bool("record", false, "re-record testdata fixtures from miracle74.com")
+
+// newFixtureClient returns a client that answers from testdata, or records
+// into it when the tests run with -record. A missing fixture fails at once
+// instead of being retried.
+func newFixtureClient(t *testing.T) *Client {
+	t.Helper()
+
+	transport := &FixtureTransport{Dir: "testdata", Record: *record}
+	return NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithScheduler(fastScheduler()),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+	)
+}
+
+func fastScheduler() *Scheduler {
+	return NewScheduler(SchedulerConfig{RequestsPerMinute: 60000, MaxConcurrent: 4})
+}
+
+func TestScrapeCharacter(t *testing.T) {
+	c := newFixtureClient(t)
+
+	character, err := c.ScrapeCharacter(context.Background(), "Oten")
+	if err != nil {
+		t.Fatalf("ScrapeCharacter() error = %v", err)
+	}
+	if character.Name != "Oten" || character.Level != 81 {
+		t.Errorf("ScrapeCharacter() = %s level %d, want Oten level 81", character.Name, character.Level)
+	}
+
+	if _, err := c.ScrapeCharacter(context.Background(), "Nobody"); !errors.Is(err, ErrCharacterNotFound) {
+		t.Errorf("ScrapeCharacter(Nobody) error = %v, want ErrCharacterNotFound", err)
+	}
+}
+
+func TestScrapeGuildNotFound(t *testing.T) {
+	c := newFixtureClient(t)
+
+	if _, err := c.ScrapeGuild(context.Background(), 999999); !errors.Is(err, ErrGuildNotFound) {
+		t.Errorf("ScrapeGuild() error = %v, want ErrGuildNotFound", err)
+	}
+}
+
+func TestScrapePowerGamersAllPages(t *testing.T) {
+	c := newFixtureClient(t)
+
+	ctx := TrackSources(context.Background())
+	list, err := c.ScrapePowerGamers(ctx, true, "today", "")
+	if err != nil {
+		t.Fatalf("ScrapePowerGamers() error = %v", err)
+	}
+	if list.Pages != 3 || len(list.PowerGamers) != 12 || len(list.FailedPages) != 0 {
+		t.Fatalf("ScrapePowerGamers() = %d rows from %d pages (%d failed), want 12 rows from 3 pages",
+			len(list.PowerGamers), list.Pages, len(list.FailedPages))
+	}
+	if sources := Sources(ctx); len(sources) != 3 {
+		t.Errorf("Sources() = %v, want the 3 pages", sources)
+	}
+	for i, pg := range list.PowerGamers {
+		if pg.Rank != i+1 {
+			t.Fatalf("row %d has rank %d, want rows in rank order", i, pg.Rank)
+		}
+	}
+}
+
+func TestScrapeInsomniacsWithoutPagination(t *testing.T) {
+	c := newFixtureClient(t)
+
+	list, err := c.ScrapeInsomniacs(context.Background(), true)
+	if err != nil {
+		t.Fatalf("ScrapeInsomniacs() error = %v", err)
+	}
+	// Page 2 is shorter than page 1, so the scrape must stop there.
+	if list.Pages != 2 || len(list.Insomniacs) != 7 {
+		t.Errorf("ScrapeInsomniacs() = %d rows from %d pages, want 7 rows from 2 pages", len(list.Insomniacs), list.Pages)
+	}
+}
+
+func TestScrapePowerGamersPartialFailure(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", FixtureName(r.URL.Query())))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithScheduler(fastScheduler()))
+	list, err := c.ScrapePowerGamers(context.Background(), true, "today", "")
+	if err != nil {
+		t.Fatalf("ScrapePowerGamers() error = %v", err)
+	}
+	if len(list.FailedPages) != 1 || list.FailedPages[0].Page != 2 {
+		t.Fatalf("FailedPages = %+v, want page 2", list.FailedPages)
+	}
+	if len(list.PowerGamers) != 7 {
+		t.Errorf("got %d rows, want the 7 rows of pages 1 and 3", len(list.PowerGamers))
+	}
+}
+
+func TestFetchRetriesRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", "whoisonline_order-name.html"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(
+		WithBaseURL(srv.URL),
+		WithScheduler(fastScheduler()),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	)
+
+	players, err := c.ScrapeWhoIsOnline(context.Background(), "name")
+	if err != nil {
+		t.Fatalf("ScrapeWhoIsOnline() error = %v", err)
+	}
+	if len(players) != 5 || calls.Load() != 3 {
+		t.Errorf("got %d players after %d calls, want 5 players after 3 calls", len(players), calls.Load())
+	}
+}
+
+func TestFetchRateLimitExhausted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := NewClient(
+		WithBaseURL(srv.URL),
+		WithScheduler(fastScheduler()),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	)
+
+	_, err := c.ScrapeWhoIsOnline(context.Background(), "name")
+
+	var rateLimited *RateLimitedError
+	if !errors.As(err, &rateLimited) || rateLimited.Attempts != 2 {
+		t.Fatalf("error = %v, want RateLimitedError after 2 attempts", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error does not match ErrRateLimited")
+	}
+}
+
+func TestFetchHonorsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithScheduler(fastScheduler()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.ScrapeCharacter(ctx, "Oten"); !errors.Is(err, ErrUpstreamTimeout) {
+		t.Errorf("ScrapeCharacter() error = %v, want ErrUpstreamTimeout", err)
+	}
+}
+
+func TestFetchPagesTrustsSinglePage(t *testing.T) {
+	var calls atomic.Int32
+	set, err := fetchPages(context.Background(), true, func(ctx context.Context, page int) ([]int, int, error) {
+		calls.Add(1)
+		return []int{1, 2, 3}, 1, nil
+	})
+	if err != nil {
+		t.Fatalf("fetchPages() error = %v", err)
+	}
+	if n := calls.Load(); n != 1 || set.pages != 1 {
+		t.Errorf("fetched %d pages (%d requests), want only page 1", set.pages, n)
+	}
+}
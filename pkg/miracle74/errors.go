@@ -0,0 +1,67 @@
+package miracle74
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrCharacterNotFound   = errors.New("character not found")
+	ErrGuildNotFound       = errors.New("guild not found")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrUpstreamTimeout     = errors.New("upstream timed out")
+	ErrRateLimited         = errors.New("rate limited by upstream")
+	ErrParse               = errors.New("failed to parse upstream page")
+)
+
+// StatusError is returned when miracle74.com answers with a non-200 status.
+// It matches ErrRateLimited for 429 and ErrUpstreamUnavailable otherwise.
+type StatusError struct {
+	StatusCode int
+	// RetryAfter is the parsed Retry-After header, if upstream sent one.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+func (e *StatusError) Is(target error) bool {
+	if e.StatusCode == http.StatusTooManyRequests {
+		return target == ErrRateLimited
+	}
+	return target == ErrUpstreamUnavailable
+}
+
+// RateLimitedError is returned once upstream has kept answering 429 until
+// the retry policy gave up.
+type RateLimitedError struct {
+	Attempts   int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by upstream after %d attempt(s), retry after %s", e.Attempts, e.RetryAfter)
+	}
+	return fmt.Sprintf("rate limited by upstream after %d attempt(s)", e.Attempts)
+}
+
+func (e *RateLimitedError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// transportError classifies a failed HTTP round trip so callers can tell a
+// timeout apart from a connection failure. The original error stays in the
+// chain, which keeps context.Canceled detectable.
+func transportError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrUpstreamTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", ErrUpstreamUnavailable, err)
+}
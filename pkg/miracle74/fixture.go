@@ -0,0 +1,100 @@
+package miracle74
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FixtureTransport is an http.RoundTripper that serves upstream pages from
+// HTML files in Dir instead of the network. With Record set it forwards
+// requests to Next (http.DefaultTransport if nil) and saves every 200
+// response to Dir, so fixtures can be refreshed from the live site.
+type FixtureTransport struct {
+	Dir    string
+	Record bool
+	Next   http.RoundTripper
+}
+
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(t.Dir, FixtureName(req.URL.Query()))
+
+	if t.Record {
+		return t.record(req, path)
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no fixture for %s: %w", req.URL, err)
+	}
+
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        "200 OK",
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *FixtureTransport) record(req *http.Request, path string) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+var unsafeFixtureChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FixtureName maps an upstream query to its fixture file name: the subtopic
+// followed by the remaining parameters in key order, for example
+// "powergamers_list-today_page-1.html".
+func FixtureName(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key != "subtopic" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := []string{query.Get("subtopic")}
+	for _, key := range keys {
+		parts = append(parts, key+"-"+query.Get(key))
+	}
+
+	name := strings.Join(parts, "_")
+	return unsafeFixtureChars.ReplaceAllString(name, "-") + ".html"
+}
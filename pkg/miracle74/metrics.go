@@ -0,0 +1,87 @@
+package miracle74
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Instruments report to the global meter provider, which is a no-op unless
+// the application installs one.
+var (
+	meter = otel.Meter("github.com/ethaan/miracle74-api/pkg/miracle74")
+
+	fetchDuration = must(meter.Float64Histogram("miracle74.upstream.fetch.duration",
+		metric.WithDescription("Duration of single upstream requests, including reading the body"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30),
+	))
+	fetchResponses = must(meter.Int64Counter("miracle74.upstream.responses",
+		metric.WithDescription("Upstream requests by status code; status is \"error\" when no response arrived"),
+	))
+	fetchRetries = must(meter.Int64Counter("miracle74.upstream.retries",
+		metric.WithDescription("Upstream requests retried, by reason"),
+	))
+	parseFailures = must(meter.Int64Counter("miracle74.parse.failures",
+		metric.WithDescription("Upstream pages that could not be parsed"),
+	))
+	parseRows = must(meter.Int64Histogram("miracle74.parse.rows",
+		metric.WithDescription("Rows parsed from a single upstream page"),
+		metric.WithExplicitBucketBoundaries(0, 1, 5, 10, 25, 50, 100, 250, 500),
+	))
+)
+
+func must[T any](instrument T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return instrument
+}
+
+// observeFetch records one upstream request. status is 0 when the request
+// failed before a response arrived.
+func observeFetch(ctx context.Context, subtopic string, status int, elapsed time.Duration) {
+	code := "error"
+	if status != 0 {
+		code = strconv.Itoa(status)
+	}
+
+	attrs := metric.WithAttributes(attribute.String("subtopic", subtopic), attribute.String("status", code))
+	fetchDuration.Record(ctx, elapsed.Seconds(), attrs)
+	fetchResponses.Add(ctx, 1, attrs)
+}
+
+// observeRetry records that a failed request to subtopic will be retried.
+func observeRetry(ctx context.Context, subtopic string, err error) {
+	reason := "unavailable"
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		reason = "rate_limited"
+	case errors.Is(err, ErrUpstreamTimeout):
+		reason = "timeout"
+	}
+
+	fetchRetries.Add(ctx, 1, metric.WithAttributes(attribute.String("subtopic", subtopic), attribute.String("reason", reason)))
+}
+
+// observeRows records how many rows a page of subtopic yielded.
+func observeRows(ctx context.Context, span trace.Span, subtopic string, rows int) {
+	span.SetAttributes(attribute.Int("miracle74.rows", rows))
+	parseRows.Record(ctx, int64(rows), metric.WithAttributes(attribute.String("subtopic", subtopic)))
+}
+
+// endParse counts err as a parse failure when the page did not have the
+// expected layout, then ends the parse span. Pages reporting a missing
+// character or guild are not failures.
+func endParse(ctx context.Context, span trace.Span, subtopic string, err error) {
+	if errors.Is(err, ErrParse) {
+		parseFailures.Add(ctx, 1, metric.WithAttributes(attribute.String("subtopic", subtopic)))
+	}
+	endSpan(span, err)
+}
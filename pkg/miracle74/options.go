@@ -0,0 +1,66 @@
+package miracle74
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another host, such as a local fake of
+// miracle74.com.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client, e.g. to plug in a
+// FixtureTransport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request. Pages that need
+// browser headers still override it.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sets where the client reports fetches, retries and parser
+// warnings. Per-page details are logged at debug level. Defaults to
+// slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithScheduler makes the client draw from a shared request budget. Pass the
+// same Scheduler to every client in the process.
+func WithScheduler(scheduler *Scheduler) Option {
+	return func(c *Client) {
+		c.scheduler = scheduler
+	}
+}
+
+// WithLocation sets the timezone the site's timestamps are read in. Defaults
+// to DefaultTimezone.
+func WithLocation(loc *time.Location) Option {
+	return func(c *Client) {
+		c.location = loc
+	}
+}
+
+// WithRetryPolicy overrides how failed requests are retried.
+func WithRetryPolicy(retry RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = retry
+	}
+}
@@ -0,0 +1,142 @@
+package miracle74
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethaan/miracle74-api/internal/types"
+)
+
+// pageWorkers is how many pages of one list are requested at the same time.
+// The shared Scheduler still decides when each request actually goes out.
+const pageWorkers = 4
+
+// maxPages bounds multi-page scrapes in case pagination cannot be detected
+// and upstream keeps serving full pages.
+const maxPages = 100
+
+// pageFetcher fetches and parses one page of a list. It returns the rows on
+// the page and the last page number linked from it, or 0 if unknown.
+type pageFetcher[T any] func(ctx context.Context, page int) ([]T, int, error)
+
+// pageSet is the outcome of a multi-page scrape.
+type pageSet[T any] struct {
+	rows   map[int][]T
+	pages  int
+	failed []types.PageFailure
+}
+
+// all returns the rows of every fetched page in page order.
+func (s *pageSet[T]) all() []T {
+	pages := make([]int, 0, len(s.rows))
+	for page := range s.rows {
+		pages = append(pages, page)
+	}
+	sort.Ints(pages)
+
+	var rows []T
+	for _, page := range pages {
+		rows = append(rows, s.rows[page]...)
+	}
+	return rows
+}
+
+// fetchPages fetches page 1 and, when includeAll is set, every following
+// page up to the last one upstream links to. Once the page count is known
+// the remaining pages are fetched in parallel. A failure on page 1 fails the
+// whole scrape; failures on later pages are recorded and the rest of the
+// list is still returned.
+func fetchPages[T any](ctx context.Context, includeAll bool, fetch pageFetcher[T]) (*pageSet[T], error) {
+	rows, lastPage, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("page 1: %w", err)
+	}
+
+	set := &pageSet[T]{rows: map[int][]T{1: rows}, pages: 1}
+	if !includeAll {
+		return set, nil
+	}
+
+	// A page count of 1 is taken at its word; only a page without readable
+	// pagination is probed.
+	if lastPage == 0 {
+		probePages(ctx, set, len(rows), fetch)
+	}
+
+	next := 2
+	for next <= lastPage && next <= maxPages {
+		end := min(lastPage, maxPages)
+		discovered := fetchRange(ctx, set, next, end, fetch)
+		next = end + 1
+		// Pagination widgets often only link a window of pages around the
+		// current one, so later pages may reveal more.
+		lastPage = max(lastPage, discovered)
+	}
+
+	// Pages that failed because the caller went away are not worth reporting.
+	if err := ctx.Err(); err != nil {
+		return nil, transportError(err)
+	}
+
+	return set, nil
+}
+
+// fetchRange fetches pages first..last with up to pageWorkers requests in
+// flight and returns the highest last page any of them linked to.
+func fetchRange[T any](ctx context.Context, set *pageSet[T], first, last int, fetch pageFetcher[T]) int {
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		discovered int
+	)
+
+	pages := make(chan int)
+	for range min(pageWorkers, last-first+1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				rows, lastPage, err := fetch(ctx, page)
+
+				mu.Lock()
+				set.pages++
+				if err != nil {
+					set.failed = append(set.failed, types.PageFailure{Page: page, Error: err.Error()})
+				} else {
+					set.rows[page] = rows
+					discovered = max(discovered, lastPage)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for page := first; page <= last; page++ {
+		pages <- page
+	}
+	close(pages)
+	wg.Wait()
+
+	sort.Slice(set.failed, func(i, j int) bool { return set.failed[i].Page < set.failed[j].Page })
+	return discovered
+}
+
+// probePages walks a list without pagination links one page at a time,
+// stopping at the first page that comes back shorter than page 1.
+func probePages[T any](ctx context.Context, set *pageSet[T], pageSize int, fetch pageFetcher[T]) {
+	for page := 2; page <= maxPages && pageSize > 0; page++ {
+		rows, _, err := fetch(ctx, page)
+		set.pages++
+		if err != nil {
+			set.failed = append(set.failed, types.PageFailure{Page: page, Error: err.Error()})
+			return
+		}
+
+		set.rows[page] = rows
+		if len(rows) < pageSize {
+			return
+		}
+	}
+}
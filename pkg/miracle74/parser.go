@@ -2,7 +2,10 @@ package miracle74
 
 import (
 	"fmt"
+	"log/slog"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,19 +14,33 @@ import (
 	"golang.org/x/net/html"
 )
 
-func parseCharacterData(doc *html.Node) (*types.Character, error) {
+// ParserVersion identifies what the parsers produce. Bump it with any change
+// to their output, such as a parsing fix or a new field, so results cached by
+// an older version are scraped again.
+const ParserVersion = 6
+
+// parseCharacterData reads a character page. Its timestamps are in the
+// server's timezone, loc.
+func parseCharacterData(doc *html.Node, loc *time.Location) (*types.Character, error) {
 	character := &types.Character{}
 
 	table := findCharacterTable(doc)
 	if table == nil {
-		return nil, fmt.Errorf("character information table not found")
+		if isMissingPage(doc) {
+			return nil, ErrCharacterNotFound
+		}
+		return nil, fmt.Errorf("%w: character information table not found", ErrParse)
 	}
 
-	parseCharacterInfo(table, character)
+	parseCharacterInfo(table, character, loc)
 
 	deathsTable := findDeathsTable(doc)
 	if deathsTable != nil {
-		character.Deaths = parseDeaths(deathsTable)
+		character.Deaths = parseDeaths(deathsTable, loc)
+	}
+
+	if accountTable := findCaptionedTable(doc, "Characters"); accountTable != nil {
+		character.AccountCharacters = parseAccountCharacters(accountTable)
 	}
 
 	return character, nil
@@ -46,12 +63,36 @@ func findCharacterTable(n *html.Node) *html.Node {
 }
 
 func findDeathsTable(n *html.Node) *html.Node {
-	if isDeathsSection(n) {
-		return findNextTable(n.Parent)
+	return findSectionTable(n, "Character Deaths")
+}
+
+// findSectionTable returns the first table after the caption reading
+// caption. Every ancestor of the caption contains its text too, so the
+// search descends to the innermost match before looking for the table.
+func findSectionTable(n *html.Node, caption string) *html.Node {
+	if !strings.Contains(getTextContent(n), caption) {
+		return nil
 	}
 
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if result := findDeathsTable(c); result != nil {
+		if result := findSectionTable(c, caption); result != nil {
+			return result
+		}
+	}
+
+	return findNextTable(n.Parent)
+}
+
+// findCaptionedTable returns the table under the box caption reading exactly
+// caption, for captions too short for findSectionTable to tell apart from
+// other text on the page.
+func findCaptionedTable(n *html.Node, caption string) *html.Node {
+	if n.Type == html.ElementNode && hasClass(n, "Text") && strings.TrimSpace(getTextContent(n)) == caption {
+		return findNextTable(n)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if result := findCaptionedTable(c, caption); result != nil {
 			return result
 		}
 	}
@@ -59,9 +100,11 @@ func findDeathsTable(n *html.Node) *html.Node {
 	return nil
 }
 
-func isDeathsSection(n *html.Node) bool {
-	text := getTextContent(n)
-	return strings.Contains(text, "Character Deaths")
+// isMissingPage reports whether the page is the site's "does not exist"
+// notice rather than a profile with a layout we failed to recognise.
+func isMissingPage(doc *html.Node) bool {
+	text := strings.ToLower(getTextContent(doc))
+	return strings.Contains(text, "does not exist") || strings.Contains(text, "doesn't exist")
 }
 
 func findNextTable(n *html.Node) *html.Node {
@@ -92,7 +135,8 @@ func findFirstTable(n *html.Node) *html.Node {
 	return nil
 }
 
-func parseCharacterInfo(table *html.Node, character *types.Character) {
+// parseCharacterInfo reads the labelled rows of the character table.
+func parseCharacterInfo(table *html.Node, character *types.Character, loc *time.Location) {
 	rows := findAllTRs(table)
 
 	for _, row := range rows {
@@ -105,6 +149,9 @@ func parseCharacterInfo(table *html.Node, character *types.Character) {
 		value := strings.TrimSpace(getTextContent(cells[1]))
 
 		switch {
+		case strings.Contains(label, "Former Names:"):
+			character.FormerNames = splitList(value)
+
 		case strings.Contains(label, "Name:"):
 			character.Name = extractName(value)
 			character.Country = extractCountry(cells[1])
@@ -126,18 +173,32 @@ func parseCharacterInfo(table *html.Node, character *types.Character) {
 		case strings.Contains(label, "Guild Membership:"):
 			character.Guild, character.GuildRank, character.GuildURL = extractGuildInfo(cells[1])
 
+		case strings.Contains(label, "Married To:"):
+			character.MarriedTo = value
+
+		case strings.Contains(label, "House:"):
+			character.House = parseHouse(value, loc)
+
+		case strings.Contains(label, "Comment:"):
+			character.Comment = getTextWithBreaks(cells[1])
+
 		case strings.Contains(label, "Last login:"):
-			if t, err := parseLastLogin(value); err == nil {
+			if t, err := parseProfileTime(value, loc); err == nil {
 				character.LastLogin = &t
 			}
 
+		case strings.Contains(label, "Created:"):
+			if t, err := parseProfileTime(value, loc); err == nil {
+				character.Created = &t
+			}
+
 		case strings.Contains(label, "Account") && strings.Contains(label, "Status:"):
 			character.IsPremium = strings.Contains(value, "Premium")
 		}
 	}
 }
 
-func parseDeaths(table *html.Node) []types.Death {
+func parseDeaths(table *html.Node, loc *time.Location) []types.Death {
 	var deaths []types.Death
 	rows := findAllTRs(table)
 
@@ -154,17 +215,81 @@ func parseDeaths(table *html.Node) []types.Death {
 		killedBy := extractKilledBy(deathInfo)
 
 		if dateStr != "" && killedBy != "" {
-			deaths = append(deaths, types.Death{
-				Date:     dateStr,
-				Level:    level,
-				KilledBy: killedBy,
-			})
+			death := types.Death{
+				Date:        dateStr,
+				Level:       level,
+				KilledBy:    killedBy,
+				Killers:     extractKillers(cells[1]),
+				Unjustified: strings.Contains(strings.ToLower(deathInfo), "(unjustified)"),
+			}
+			if t, err := time.ParseInLocation("2.1.2006, 15:04:05", dateStr, loc); err == nil {
+				death.Time = &t
+			}
+			for _, killer := range death.Killers {
+				death.PvP = death.PvP || killer.Player
+			}
+			deaths = append(deaths, death)
 		}
 	}
 
 	return deaths
 }
 
+// parseAccountCharacters reads the list of characters on the account. Names
+// are numbered ("1. Oten") and the status is Online, Offline or a note such
+// as "deleted".
+func parseAccountCharacters(table *html.Node) []types.AccountCharacter {
+	var characters []types.AccountCharacter
+
+	for _, row := range findAllTRs(table) {
+		cells := findAllTDs(row)
+		if len(cells) < 3 {
+			continue
+		}
+
+		name := extractNameFromLink(cells[0])
+		if name == "" {
+			continue // Header row
+		}
+
+		characters = append(characters, types.AccountCharacter{
+			Name:   name,
+			World:  strings.TrimSpace(getTextContent(cells[1])),
+			Status: strings.TrimSpace(getTextContent(cells[2])),
+		})
+	}
+
+	return characters
+}
+
+// housePattern matches "Market Street 4 (Venore) is paid until 3 January
+// 2026"; the town and the date may be missing.
+var housePattern = regexp.MustCompile(`^(.+?)(?: \(([^)]+)\))?(?: is paid until (.+))?$`)
+
+func parseHouse(value string, loc *time.Location) *types.House {
+	m := housePattern.FindStringSubmatch(value)
+	if m == nil {
+		return nil
+	}
+
+	house := &types.House{Name: m[1], Town: m[2]}
+	if t, err := time.ParseInLocation("2 January 2006", m[3], loc); err == nil {
+		house.PaidUntil = &t
+	}
+	return house
+}
+
+// splitList splits a comma-separated list such as "Oten Sorc, Little Oten".
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func hasClass(n *html.Node, class string) bool {
 	for _, attr := range n.Attr {
 		if attr.Key == "class" && strings.Contains(attr.Val, class) {
@@ -186,6 +311,59 @@ func getTextContent(n *html.Node) string {
 	return text
 }
 
+// getTextWithBreaks is getTextContent with <br> turned into newlines and
+// the lines trimmed.
+func getTextWithBreaks(n *html.Node) string {
+	var text strings.Builder
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			text.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			text.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(n)
+
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findElementWithClass returns the first tag element having class.
+func findElementWithClass(n *html.Node, tag, class string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag && hasClass(n, class) {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if result := findElementWithClass(c, tag, class); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// absoluteURL resolves a link found on the site.
+func absoluteURL(href string) string {
+	switch {
+	case strings.HasPrefix(href, "?"):
+		return "https://miracle74.com/" + href
+	case strings.HasPrefix(href, "/"):
+		return "https://miracle74.com" + href
+	default:
+		return href
+	}
+}
+
 func findAllTRs(n *html.Node) []*html.Node {
 	var rows []*html.Node
 
@@ -287,11 +465,8 @@ func extractGuildInfo(cell *html.Node) (guildName, guildRank, guildURL string) {
 	}
 
 	textBeforeLink = strings.TrimSpace(textBeforeLink)
-	if strings.Contains(textBeforeLink, " of the ") {
-		parts := strings.Split(textBeforeLink, " of the ")
-		if len(parts) > 0 {
-			guildRank = strings.TrimSpace(parts[0])
-		}
+	if rank, _, found := strings.Cut(textBeforeLink, " of the"); found {
+		guildRank = strings.TrimSpace(rank)
 	} else {
 		guildRank = textBeforeLink
 	}
@@ -299,19 +474,15 @@ func extractGuildInfo(cell *html.Node) (guildName, guildRank, guildURL string) {
 	return guildName, guildRank, guildURL
 }
 
-func parseLastLogin(value string) (time.Time, error) {
-	layouts := []string{
-		"2 January 2006, 3:04 pm",
-		"2 January 2006, 3:04 am",
+// parseProfileTime parses the timestamps on a character page, such as
+// "17 December 2025, 5:09 am".
+func parseProfileTime(value string, loc *time.Location) (time.Time, error) {
+	// "pm" in a layout matches both "am" and "pm".
+	t, err := time.ParseInLocation("2 January 2006, 3:04 pm", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse time: %s", value)
 	}
-
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, value); err == nil {
-			return t, nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("failed to parse last login: %s", value)
+	return t, nil
 }
 
 func extractDeathLevel(deathInfo string) int {
@@ -326,22 +497,78 @@ func extractDeathLevel(deathInfo string) int {
 }
 
 func extractKilledBy(deathInfo string) string {
-	parts := strings.Split(deathInfo, " by ")
-	if len(parts) > 1 {
-		return strings.TrimSpace(parts[1])
+	if _, killedBy, found := strings.Cut(deathInfo, " by "); found {
+		return strings.TrimSpace(killedBy)
 	}
 	return ""
 }
 
-func parsePowerGamersData(doc *html.Node) ([]types.PowerGamer, error) {
+// killerSeparator splits "Foo, Bar Baz and a demon skeleton".
+var killerSeparator = regexp.MustCompile(`,\s*|\s+and\s+`)
+
+// playerPlaceholder stands in for a character link while the killer list is
+// split, so a name such as "Rock and Roll" stays whole.
+var playerPlaceholder = regexp.MustCompile(`^\x00(\d+)\x00$`)
+
+// extractKillers lists who is named after "by" in a death description.
+// Killers linked to their character page are players, the rest monsters or
+// other causes, named as shown ("a dragon lord").
+func extractKillers(cell *html.Node) []types.Killer {
+	var text strings.Builder
+	var players []string
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			fmt.Fprintf(&text, "\x00%d\x00", len(players))
+			players = append(players, strings.TrimSpace(getTextContent(n)))
+			return
+		}
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(cell)
+
+	_, list, found := strings.Cut(strings.Join(strings.Fields(text.String()), " "), " by ")
+	if !found {
+		return nil
+	}
+	if open := strings.LastIndex(list, " ("); open >= 0 && strings.HasSuffix(list, ")") {
+		list = list[:open] // "(unjustified)"
+	}
+	list = strings.TrimSuffix(list, ".")
+
+	var killers []types.Killer
+	for _, name := range killerSeparator.Split(list, -1) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if m := playerPlaceholder.FindStringSubmatch(name); m != nil {
+			i, _ := strconv.Atoi(m[1])
+			killers = append(killers, types.Killer{Name: players[i], Player: true})
+		} else {
+			killers = append(killers, types.Killer{Name: name})
+		}
+	}
+
+	return killers
+}
+
+func parsePowerGamersData(doc *html.Node, logger *slog.Logger) ([]types.PowerGamer, error) {
 	table := findPowerGamersTable(doc)
 	if table == nil {
-		return nil, fmt.Errorf("power gamers table not found")
+		return nil, fmt.Errorf("%w: power gamers table not found", ErrParse)
 	}
 
 	rows := findAllTRs(table)
 	if len(rows) == 0 {
-		return nil, fmt.Errorf("no rows found in power gamers table")
+		return nil, fmt.Errorf("%w: no rows found in power gamers table", ErrParse)
 	}
 
 	var powerGamers []types.PowerGamer
@@ -371,19 +598,19 @@ func parsePowerGamersData(doc *html.Node) ([]types.PowerGamer, error) {
 
 		rank, err := strconv.Atoi(rankStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse rank '%s': %v\n", rankStr, err)
+			logger.Warn("skipping row with unparsable rank", "value", rankStr, "error", err)
 			continue
 		}
 
 		level, err := strconv.Atoi(levelStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse level '%s': %v\n", levelStr, err)
+			logger.Warn("skipping row with unparsable level", "value", levelStr, "error", err)
 			continue
 		}
 
 		today, err := strconv.Atoi(todayStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse today '%s': %v\n", todayStr, err)
+			logger.Warn("skipping row with unparsable today", "value", todayStr, "error", err)
 			continue
 		}
 
@@ -432,15 +659,15 @@ func findTBody(n *html.Node) *html.Node {
 	return nil
 }
 
-func parseWhoIsOnlineData(doc *html.Node) ([]types.OnlinePlayer, error) {
+func parseWhoIsOnlineData(doc *html.Node, logger *slog.Logger) ([]types.OnlinePlayer, error) {
 	table := findWhoIsOnlineTable(doc)
 	if table == nil {
-		return nil, fmt.Errorf("who is online table not found")
+		return nil, fmt.Errorf("%w: who is online table not found", ErrParse)
 	}
 
 	rows := findAllTRs(table)
 	if len(rows) == 0 {
-		return nil, fmt.Errorf("no rows found in who is online table")
+		return nil, fmt.Errorf("%w: no rows found in who is online table", ErrParse)
 	}
 
 	var onlinePlayers []types.OnlinePlayer
@@ -481,7 +708,7 @@ func parseWhoIsOnlineData(doc *html.Node) ([]types.OnlinePlayer, error) {
 
 		level, err := strconv.Atoi(levelStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse level '%s': %v\n", levelStr, err)
+			logger.Warn("skipping row with unparsable level", "value", levelStr, "error", err)
 			continue
 		}
 
@@ -527,15 +754,15 @@ func getAttr(n *html.Node, key string) string {
 	return ""
 }
 
-func parseInsomniacsData(doc *html.Node) ([]types.Insomniac, error) {
+func parseInsomniacsData(doc *html.Node, logger *slog.Logger) ([]types.Insomniac, error) {
 	table := findInsomniacsTable(doc)
 	if table == nil {
-		return nil, fmt.Errorf("insomniacs table not found")
+		return nil, fmt.Errorf("%w: insomniacs table not found", ErrParse)
 	}
 
 	rows := findAllTRs(table)
 	if len(rows) == 0 {
-		return nil, fmt.Errorf("no rows found in insomniacs table")
+		return nil, fmt.Errorf("%w: no rows found in insomniacs table", ErrParse)
 	}
 
 	var insomniacs []types.Insomniac
@@ -565,13 +792,13 @@ func parseInsomniacsData(doc *html.Node) ([]types.Insomniac, error) {
 
 		rank, err := strconv.Atoi(rankStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse rank '%s': %v\n", rankStr, err)
+			logger.Warn("skipping row with unparsable rank", "value", rankStr, "error", err)
 			continue
 		}
 
 		name := extractNameFromLink(nameCell)
 		if name == "" {
-			fmt.Printf("Warning: failed to extract name from cell\n")
+			logger.Warn("skipping row without a character link")
 			continue
 		}
 
@@ -579,7 +806,7 @@ func parseInsomniacsData(doc *html.Node) ([]types.Insomniac, error) {
 
 		level, err := strconv.Atoi(levelStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse level '%s': %v\n", levelStr, err)
+			logger.Warn("skipping row with unparsable level", "value", levelStr, "error", err)
 			continue
 		}
 
@@ -637,18 +864,22 @@ func findFirstLink(n *html.Node) *html.Node {
 	return nil
 }
 
-func parseGuildData(doc *html.Node, guildID int) (*types.Guild, error) {
+func parseGuildData(doc *html.Node, guildID int, logger *slog.Logger) (*types.Guild, error) {
 	table := findGuildMembersTable(doc)
 	if table == nil {
-		return nil, fmt.Errorf("guild members table not found")
+		if isMissingPage(doc) {
+			return nil, ErrGuildNotFound
+		}
+		return nil, fmt.Errorf("%w: guild members table not found", ErrParse)
 	}
 
 	rows := findAllTRs(table)
 	if len(rows) == 0 {
-		return nil, fmt.Errorf("no rows found in guild members table")
+		return nil, fmt.Errorf("%w: no rows found in guild members table", ErrParse)
 	}
 
-	var members []types.GuildMember
+	guild := &types.Guild{GuildID: guildID}
+	parseGuildProfile(doc, guild, logger)
 
 	for i, row := range rows {
 		if i == 0 {
@@ -672,34 +903,217 @@ func parseGuildData(doc *html.Node, guildID int) (*types.Guild, error) {
 		if rank == "" {
 			continue
 		}
+		// Ranks are listed highest first.
+		if !slices.Contains(guild.Ranks, rank) {
+			guild.Ranks = append(guild.Ranks, rank)
+		}
 
 		name := extractNameFromLink(nameCell)
 		if name == "" {
-			fmt.Printf("Warning: failed to extract name from cell\n")
+			logger.Warn("skipping row without a character link")
 			continue
 		}
 
 		level, err := strconv.Atoi(levelStr)
 		if err != nil {
-			fmt.Printf("Warning: failed to parse level '%s': %v\n", levelStr, err)
+			logger.Warn("skipping row with unparsable level", "value", levelStr, "error", err)
 			continue
 		}
 
-		status := extractGuildMemberStatus(statusCell)
-
-		members = append(members, types.GuildMember{
+		member := types.GuildMember{
 			Rank:     rank,
 			Name:     name,
+			Title:    extractGuildMemberTitle(nameCell),
 			Vocation: vocation,
 			Level:    level,
-			Status:   status,
+			Status:   extractGuildMemberStatus(statusCell),
+		}
+		if len(cells) > 5 {
+			member.Joined = parseGuildDate(strings.TrimSpace(getTextContent(cells[5])), logger)
+		}
+
+		guild.Members = append(guild.Members, member)
+	}
+
+	if invites := findSectionTable(doc, "Invited Characters"); invites != nil {
+		guild.Invites = parseGuildInvites(invites, logger)
+	}
+
+	return guild, nil
+}
+
+// foundedPattern matches "The guild was founded on <world> on <date>."
+var foundedPattern = regexp.MustCompile(`founded on .+ on (\d{1,2} \w+ \d{4})`)
+
+// parseGuildProfile fills in what the page says about the guild above its
+// member list. Everything but the name is optional, so a missing name is
+// logged: it means the profile markup isn't what the parser expects.
+func parseGuildProfile(doc *html.Node, guild *types.Guild, logger *slog.Logger) {
+	if name := findElementWithClass(doc, "h1", "GuildName"); name != nil {
+		guild.Name = strings.TrimSpace(getTextContent(name))
+	} else {
+		logger.Warn("guild profile not found, leaving it empty", "guild_id", guild.GuildID)
+	}
+	if logo := findElementWithClass(doc, "img", "GuildLogo"); logo != nil {
+		guild.LogoURL = absoluteURL(getAttr(logo, "src"))
+	}
+	if description := findElementWithClass(doc, "td", "GuildDescription"); description != nil {
+		guild.Description = getTextWithBreaks(description)
+	}
+
+	if info := findElementWithClass(doc, "table", "GuildInformation"); info != nil {
+		for _, row := range findAllTRs(info) {
+			if m := foundedPattern.FindStringSubmatch(getTextContent(row)); m != nil {
+				guild.Founded = parseGuildDate(m[1], logger)
+			}
+		}
+	}
+}
+
+func parseGuildInvites(table *html.Node, logger *slog.Logger) []types.GuildInvite {
+	var invites []types.GuildInvite
+
+	for _, row := range findAllTRs(table) {
+		cells := findAllTDs(row)
+		if len(cells) < 2 {
+			continue
+		}
+
+		name := extractNameFromLink(cells[0])
+		if name == "" {
+			continue // Header row
+		}
+
+		invites = append(invites, types.GuildInvite{
+			Name:      name,
+			InvitedAt: parseGuildDate(strings.TrimSpace(getTextContent(cells[1])), logger),
 		})
 	}
 
-	return &types.Guild{
-		GuildID: guildID,
-		Members: members,
-	}, nil
+	return invites
+}
+
+// extractGuildMemberTitle returns the nickname shown in parentheses after a
+// member's name, if any.
+func extractGuildMemberTitle(cell *html.Node) string {
+	text := strings.TrimSpace(getTextContent(cell))
+	open := strings.Index(text, "(")
+	if open < 0 || !strings.HasSuffix(text, ")") {
+		return ""
+	}
+	return strings.TrimSpace(text[open+1 : len(text)-1])
+}
+
+// parseGuildDate parses dates such as "14 February 2024" or "14 Feb 2024",
+// returning nil for anything else.
+func parseGuildDate(value string, logger *slog.Logger) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range []string{"2 January 2006", "2 Jan 2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+
+	logger.Warn("ignoring unparsable guild date", "value", value)
+	return nil
+}
+
+func parseGuildListData(doc *html.Node, logger *slog.Logger) ([]types.GuildSummary, error) {
+	table := findSectionTable(doc, "Active Guilds")
+	if table == nil {
+		table = findGuildListTable(doc)
+	}
+	if table == nil {
+		return nil, fmt.Errorf("%w: guild list table not found", ErrParse)
+	}
+
+	var guilds []types.GuildSummary
+	for _, row := range findAllTRs(table) {
+		cells := findAllTDs(row)
+		if len(cells) < 3 {
+			continue
+		}
+
+		link := findFirstLink(cells[1])
+		if link == nil {
+			continue // Header row
+		}
+
+		guildID := linkedGuild(getAttr(link, "href"))
+		if guildID == 0 {
+			logger.Warn("skipping guild without an ID", "href", getAttr(link, "href"))
+			continue
+		}
+
+		guild := types.GuildSummary{
+			GuildID: guildID,
+			Name:    strings.TrimSpace(getTextContent(link)),
+		}
+
+		// The description follows the name, one line per <br>.
+		if _, description, found := strings.Cut(getTextWithBreaks(cells[1]), "\n"); found {
+			guild.Description = description
+		}
+		if logo := findFirstImg(cells[0]); logo != nil {
+			guild.LogoURL = absoluteURL(getAttr(logo, "src"))
+		}
+
+		membersStr := strings.TrimSpace(getTextContent(cells[2]))
+		if members, err := strconv.Atoi(membersStr); err == nil {
+			guild.Members = members
+		} else {
+			logger.Warn("ignoring unparsable member count", "guild_id", guildID, "value", membersStr)
+		}
+
+		guilds = append(guilds, guild)
+	}
+
+	return guilds, nil
+}
+
+// linkedGuild returns the ID of the guild a profile link points to, or 0.
+// findGuildListTable returns the innermost content table linking to a guild,
+// for guild lists without the "Active Guilds" caption.
+func findGuildListTable(n *html.Node) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if result := findGuildListTable(c); result != nil {
+			return result
+		}
+	}
+
+	if n.Type == html.ElementNode && n.Data == "table" && hasClass(n, "TableContent") && linksToGuild(n) {
+		return n
+	}
+	return nil
+}
+
+func linksToGuild(n *html.Node) bool {
+	if n.Type == html.ElementNode && n.Data == "a" && linkedGuild(getAttr(n, "href")) != 0 {
+		return true
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if linksToGuild(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func linkedGuild(href string) int {
+	_, rawQuery, _ := strings.Cut(href, "?")
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return 0
+	}
+
+	guildID, err := strconv.Atoi(q.Get("guild"))
+	if err != nil {
+		return 0
+	}
+	return guildID
 }
 
 func findGuildMembersTable(n *html.Node) *html.Node {
@@ -730,3 +1144,42 @@ func extractGuildMemberStatus(cell *html.Node) string {
 
 	return "Offline"
 }
+
+// parseLastPage returns the highest page number linked from the pagination
+// of a subtopic list, or 0 when the page has no pagination links.
+func parseLastPage(doc *html.Node, subtopic string) int {
+	lastPage := 0
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if page := linkedPage(getAttr(n, "href"), subtopic); page > lastPage {
+				lastPage = page
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(doc)
+
+	return lastPage
+}
+
+func linkedPage(href, subtopic string) int {
+	_, rawQuery, found := strings.Cut(href, "?")
+	if !found {
+		return 0
+	}
+
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil || q.Get("subtopic") != subtopic {
+		return 0
+	}
+
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil {
+		return 0
+	}
+	return page
+}
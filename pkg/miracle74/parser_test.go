@@ -0,0 +1,241 @@
+package miracle74
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethaan/miracle74-api/internal/types"
+	"golang.org/x/net/html"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files from the current parser output")
+
+var discardLogger = slog.New(slog.DiscardHandler)
+
+func loadFixture(t *testing.T, name string) *html.Node {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+	return doc
+}
+
+// assertGolden compares got, as indented JSON, with testdata/<name>.golden.json.
+// Run the tests with -update to accept new parser output.
+func assertGolden(t *testing.T, name string, got any) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(got); err != nil {
+		t.Fatalf("failed to marshal parser output: %v", err)
+	}
+	data := buf.Bytes()
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("parser output does not match %s (run with -update if the change is intended)\ngot:\n%s", path, data)
+	}
+}
+
+// listPage is what the golden files record for one page of a ranked list.
+type listPage[T any] struct {
+	Rows     []T `json:"rows"`
+	LastPage int `json:"last_page"`
+}
+
+func TestParsersGolden(t *testing.T) {
+	tests := []struct {
+		fixture string
+		parse   func(doc *html.Node) (any, error)
+	}{
+		{"characters_name-Oten.html", func(doc *html.Node) (any, error) {
+			return parseCharacterData(doc, defaultLocation)
+		}},
+		{"guilds_action-show_guild-386.html", func(doc *html.Node) (any, error) {
+			return parseGuildData(doc, 386, discardLogger)
+		}},
+		{"guilds.html", func(doc *html.Node) (any, error) {
+			return parseGuildListData(doc, discardLogger)
+		}},
+		{"whoisonline_order-name.html", func(doc *html.Node) (any, error) {
+			return parseWhoIsOnlineData(doc, discardLogger)
+		}},
+	}
+
+	for _, page := range []string{"1", "2", "3"} {
+		tests = append(tests, struct {
+			fixture string
+			parse   func(doc *html.Node) (any, error)
+		}{"powergamers_list-today_page-" + page + ".html", func(doc *html.Node) (any, error) {
+			rows, err := parsePowerGamersData(doc, discardLogger)
+			return listPage[any]{Rows: toAny(rows), LastPage: parseLastPage(doc, "powergamers")}, err
+		}})
+	}
+
+	for _, page := range []string{"1", "2"} {
+		tests = append(tests, struct {
+			fixture string
+			parse   func(doc *html.Node) (any, error)
+		}{"insomniacs_page-" + page + ".html", func(doc *html.Node) (any, error) {
+			rows, err := parseInsomniacsData(doc, discardLogger)
+			return listPage[any]{Rows: toAny(rows), LastPage: parseLastPage(doc, "insomniacs")}, err
+		}})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			got, err := tt.parse(loadFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("parser failed: %v", err)
+			}
+			assertGolden(t, strings.TrimSuffix(tt.fixture, ".html"), got)
+		})
+	}
+}
+
+func toAny[T any](rows []T) []any {
+	out := make([]any, len(rows))
+	for i, row := range rows {
+		out[i] = row
+	}
+	return out
+}
+
+func TestParseMissingPages(t *testing.T) {
+	if _, err := parseCharacterData(loadFixture(t, "characters_name-Nobody.html"), defaultLocation); !errors.Is(err, ErrCharacterNotFound) {
+		t.Errorf("parseCharacterData() error = %v, want ErrCharacterNotFound", err)
+	}
+	if _, err := parseGuildData(loadFixture(t, "guilds_action-show_guild-999999.html"), 999999, discardLogger); !errors.Is(err, ErrGuildNotFound) {
+		t.Errorf("parseGuildData() error = %v, want ErrGuildNotFound", err)
+	}
+}
+
+func TestParseChangedLayout(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><table class="Renamed"><tr><td>Name:</td><td>Oten</td></tr></table></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parseCharacterData(doc, defaultLocation); !errors.Is(err, ErrParse) {
+		t.Errorf("parseCharacterData() error = %v, want ErrParse", err)
+	}
+	if _, err := parsePowerGamersData(doc, discardLogger); !errors.Is(err, ErrParse) {
+		t.Errorf("parsePowerGamersData() error = %v, want ErrParse", err)
+	}
+}
+
+func TestParseGuildSkipsBlankRanks(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><table class="TableContent InnerBorder">
+<tr><td>Rank</td><td>Name and Title</td><td>Vocation</td><td>Level</td><td>Status</td></tr>
+<tr><td>Leader</td><td><a href="?subtopic=characters&name=A">Alpha</a></td><td>Knight</td><td>100</td><td>Online</td></tr>
+<tr><td>Member</td><td><a href="?subtopic=characters&name=B">Bravo</a> (Rookie)</td><td>Druid</td><td>50</td><td>Offline</td></tr>
+<tr><td></td><td><a href="?subtopic=characters&name=C">Charlie</a></td><td>Sorcerer</td><td>20</td><td>Offline</td></tr>
+</table></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guild, err := parseGuildData(doc, 1, discardLogger)
+	if err != nil {
+		t.Fatalf("parseGuildData() error = %v", err)
+	}
+	if len(guild.Members) != 2 || guild.Members[1].Title != "Rookie" {
+		t.Errorf("Members = %+v, want Alpha and Bravo (Rookie) without the unranked row", guild.Members)
+	}
+	if strings.Join(guild.Ranks, ",") != "Leader,Member" {
+		t.Errorf("Ranks = %v, want [Leader Member]", guild.Ranks)
+	}
+}
+
+func TestParseGuildListWithoutCaption(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><table class="TableContent">
+<tr><td>Logo</td><td>Description</td><td>Members</td></tr>
+<tr><td></td><td><a href="?subtopic=guilds&action=show&guild=386">Devastation</a></td><td>4</td></tr>
+</table></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guilds, err := parseGuildListData(doc, discardLogger)
+	if err != nil {
+		t.Fatalf("parseGuildListData() error = %v", err)
+	}
+	if len(guilds) != 1 || guilds[0].GuildID != 386 || guilds[0].Members != 4 {
+		t.Errorf("parseGuildListData() = %+v, want Devastation with 4 members", guilds)
+	}
+}
+
+func TestParseHouse(t *testing.T) {
+	tests := []struct {
+		value, name, town string
+		paid              bool
+	}{
+		{"Market Street 4 (Venore) is paid until 3 January 2026", "Market Street 4", "Venore", true},
+		{"Market Street 4 (Venore)", "Market Street 4", "Venore", false},
+		{"Harbour Flats, Flat 02 is paid until 3 January 2026", "Harbour Flats, Flat 02", "", true},
+	}
+
+	for _, tt := range tests {
+		house := parseHouse(tt.value, defaultLocation)
+		if house == nil || house.Name != tt.name || house.Town != tt.town || (house.PaidUntil != nil) != tt.paid {
+			t.Errorf("parseHouse(%q) = %+v, want %s in %q, paid %v", tt.value, house, tt.name, tt.town, tt.paid)
+			continue
+		}
+		if house.PaidUntil != nil && house.PaidUntil.Location() != defaultLocation {
+			t.Errorf("parseHouse(%q) paid until %v, want server time", tt.value, house.PaidUntil)
+		}
+	}
+}
+
+func TestParseProfileTime(t *testing.T) {
+	for value, want := range map[string]string{
+		"17 December 2025, 5:09 am": "2025-12-17T05:09:00+01:00",
+		"2 March 2024, 8:15 pm":     "2024-03-02T20:15:00+01:00",
+	} {
+		got, err := parseProfileTime(value, defaultLocation)
+		if err != nil || got.Format(time.RFC3339) != want {
+			t.Errorf("parseProfileTime(%q) = %v, %v, want %s", value, got, err, want)
+		}
+	}
+}
+
+func TestExtractKillers(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<table><tr><td>Killed at level 50 by <a href="?subtopic=characters&name=Rock+and+Roll">Rock and Roll</a> and a rotworm.</td></tr></table>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	killers := extractKillers(findAllTDs(doc)[0])
+	if len(killers) != 2 || killers[0] != (types.Killer{Name: "Rock and Roll", Player: true}) || killers[1] != (types.Killer{Name: "a rotworm"}) {
+		t.Errorf("extractKillers() = %+v, want player Rock and Roll and a rotworm", killers)
+	}
+}
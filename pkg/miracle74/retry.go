@@ -0,0 +1,85 @@
+package miracle74
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = 4
+	DefaultBaseDelay   = 1 * time.Second
+	DefaultMaxDelay    = 30 * time.Second
+)
+
+// RetryPolicy decides whether and when a failed upstream request is tried
+// again. Zero fields fall back to the defaults above.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles with
+	// every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than this is not
+	// waited out: the request fails with a RateLimitedError instead.
+	MaxDelay time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultMaxDelay
+	}
+	return p
+}
+
+// backoff returns the delay before attempt+1. It is exponential in attempt,
+// capped at MaxDelay, and randomised over its upper half so concurrent
+// retries spread out. A Retry-After from upstream is used as a lower bound.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 30 {
+		delay = min(p.MaxDelay, p.BaseDelay<<shift)
+	}
+
+	half := delay / 2
+	delay = half + time.Duration(rand.Int63n(int64(half)+1))
+
+	return max(delay, retryAfter)
+}
+
+// retryable reports whether err is worth another attempt: 429s, 5xx
+// responses and transport failures are; anything else is not.
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return errors.Is(err, ErrUpstreamTimeout) || errors.Is(err, ErrUpstreamUnavailable)
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(t))
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package miracle74
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRequestsPerMinute = 30
+	DefaultMaxConcurrent     = 2
+	DefaultJitter            = 750 * time.Millisecond
+)
+
+type SchedulerConfig struct {
+	// RequestsPerMinute is the sustained request budget towards miracle74.com.
+	RequestsPerMinute int
+	// Burst is how many requests may go out back to back after an idle
+	// period. Defaults to MaxConcurrent.
+	Burst int
+	// MaxConcurrent caps the number of requests in flight at once.
+	MaxConcurrent int
+	// Jitter adds a random delay of up to this duration before every request.
+	Jitter time.Duration
+}
+
+// Scheduler is the politeness gate every upstream request goes through. It
+// combines a token bucket (the requests-per-minute budget) with a
+// concurrency cap and a little jitter so our traffic never looks like a
+// burst, regardless of how many API callers are waiting on it. A single
+// Scheduler is meant to be shared by every Client in the process.
+type Scheduler struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	rate     float64 // tokens per second
+	last     time.Time
+
+	slots  chan struct{}
+	jitter time.Duration
+}
+
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	if cfg.RequestsPerMinute <= 0 {
+		cfg.RequestsPerMinute = DefaultRequestsPerMinute
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = DefaultMaxConcurrent
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.MaxConcurrent
+	}
+	if cfg.Jitter < 0 {
+		cfg.Jitter = 0
+	}
+
+	return &Scheduler{
+		tokens:   float64(cfg.Burst),
+		capacity: float64(cfg.Burst),
+		rate:     float64(cfg.RequestsPerMinute) / 60,
+		last:     time.Now(),
+		slots:    make(chan struct{}, cfg.MaxConcurrent),
+		jitter:   cfg.Jitter,
+	}
+}
+
+// Acquire blocks until a request may be sent, or ctx is done. On success the
+// caller must invoke release once the response has been fully read.
+func (s *Scheduler) Acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release = func() { <-s.slots }
+
+	wait := s.reserve()
+	if s.jitter > 0 {
+		wait += time.Duration(rand.Int63n(int64(s.jitter)))
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		s.cancelReservation()
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// reserve takes a token from the bucket and returns how long the caller has
+// to wait before the token is actually available.
+func (s *Scheduler) reserve() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens = min(s.capacity, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+	s.tokens--
+
+	if s.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-s.tokens / s.rate * float64(time.Second))
+}
+
+// cancelReservation hands back a token taken by a caller that gave up
+// waiting for it.
+func (s *Scheduler) cancelReservation() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = min(s.capacity, s.tokens+1)
+}
@@ -0,0 +1,42 @@
+package miracle74
+
+import (
+	"context"
+	"sync"
+)
+
+type sourcesKey struct{}
+
+// sourceList collects the URLs fetched under a context, possibly from
+// several pages being fetched at once.
+type sourceList struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+// TrackSources returns a copy of ctx in which the client notes every page
+// it fetches successfully, for Sources to report.
+func TrackSources(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sourcesKey{}, &sourceList{})
+}
+
+// Sources returns the URLs of the pages fetched so far under ctx, which
+// must come from TrackSources, in the order they arrived.
+func Sources(ctx context.Context) []string {
+	list, ok := ctx.Value(sourcesKey{}).(*sourceList)
+	if !ok {
+		return nil
+	}
+
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	return append([]string(nil), list.urls...)
+}
+
+func addSource(ctx context.Context, url string) {
+	if list, ok := ctx.Value(sourcesKey{}).(*sourceList); ok {
+		list.mu.Lock()
+		list.urls = append(list.urls, url)
+		list.mu.Unlock()
+	}
+}
@@ -0,0 +1,19 @@
+package miracle74
+
+import (
+	"time"
+	_ "time/tzdata" // The runtime image has no zoneinfo.
+)
+
+// DefaultTimezone is the zone the site's timestamps are written in.
+const DefaultTimezone = "Europe/Berlin"
+
+var defaultLocation = mustLoadLocation(DefaultTimezone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
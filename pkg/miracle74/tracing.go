@@ -0,0 +1,20 @@
+package miracle74
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer reports fetch, parse and scrape spans to the global tracer
+// provider, which is a no-op unless the application installs one.
+var tracer = otel.Tracer("github.com/ethaan/miracle74-api/pkg/miracle74")
+
+// endSpan marks span as failed when err is set and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}